@@ -0,0 +1,82 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// NothingVisible is an error returned when rendering a layout in which every
+// item is hidden, if set to with SetAllHidden.
+var NothingVisible = fmt.Errorf("All items are hidden")
+
+// allHiddenView is the name of the view covering the layout when every item is
+// hidden.
+const allHiddenView = "_layoutAllHidden"
+
+// AllHiddenPolicy selects what is displayed when every item of the layout is
+// hidden.
+type AllHiddenPolicy int
+
+const (
+	// AllHiddenBlank leaves the space of the layout empty.
+	AllHiddenBlank AllHiddenPolicy = iota
+	// AllHiddenPlaceholder displays a message in the space of the layout.
+	AllHiddenPlaceholder
+	// AllHiddenError fails rendering the layout with NothingVisible.
+	AllHiddenError
+)
+
+// SetAllHidden sets what is displayed when every item of the layout is hidden.
+// Until it is set, nothing is done about it, and whatever hidden view happens
+// to be on top is seen.
+func (l *layoutLevel) SetAllHidden(policy AllHiddenPolicy) {
+	l.allHiddenPolicy = policy
+	l.allHiddenSet = true
+}
+
+// AllHidden reports if every item of the layout is hidden, so nothing is
+// displayed.
+func (l *layoutLevel) AllHidden() bool {
+	if l.takeover != nil {
+		return l.takeover.AllHidden()
+	}
+	if l.zoomed != nil {
+		return l.zoomed.inner != nil && bool(l.zoomed.inner.allHidden())
+	}
+	return bool(l.allHidden())
+}
+
+// layoutAllHidden covers the layout when all its items are hidden, or removes
+// the cover once some are displayed again. Layouts rendered within others,
+// such as modals, share the view, which is only removed by the layout that
+// placed it. Nothing is covered unless SetAllHidden was called.
+func (l *layoutLevel) layoutAllHidden(g *gocui.Gui, x0, y0, x1, y1 int) error {
+	if !l.allHiddenSet {
+		return nil
+	}
+	if !l.AllHidden() {
+		if !l.allHiddenShown {
+			return nil
+		}
+		l.allHiddenShown = false
+		if err := g.DeleteView(allHiddenView); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		return nil
+	}
+	l.allHiddenShown = true
+
+	v, err := g.SetView(allHiddenView, x0-1, y0-1, x1+1, y1+1, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Frame = false
+	v.Clear()
+	if l.allHiddenPolicy == AllHiddenPlaceholder {
+		fmt.Fprintln(v, "Nothing to display")
+	}
+
+	_, err = g.SetViewOnTop(allHiddenView)
+	return err
+}
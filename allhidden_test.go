@@ -0,0 +1,120 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAllHidden(t *testing.T) {
+	tests := []struct {
+		desc   string
+		policy AllHiddenPolicy
+		want   string
+		err    error
+	}{
+		{"blank", AllHiddenBlank, "", nil},
+		{"placeholder", AllHiddenPlaceholder, "Nothing to display\n", nil},
+		{"error", AllHiddenError, "", NothingVisible},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := newTestGui(t, false)
+			l := NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "a"),
+				NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+					NewRatioItem(1, "b"),
+				))),
+			)
+			l.SetAllHidden(tc.policy)
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			if l.AllHidden() {
+				t.Errorf("Expected visible items")
+			}
+
+			for _, name := range []string{"a", "b"} {
+				if err := l.HideItem(name, LayoutHidden); err != nil {
+					t.Fatalf("HideItem failed: %v", err)
+				}
+			}
+			if !l.AllHidden() {
+				t.Errorf("Expected all items to be hidden")
+			}
+			err := l.Layout(g)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("Expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			v, err := g.View(allHiddenView)
+			if err != nil {
+				t.Fatalf("Cover view not found: %v", err)
+			}
+			if got := v.Buffer(); got != tc.want {
+				t.Errorf("Unexpected cover: want %q, got %q", tc.want, got)
+			}
+			if top := g.Views()[len(g.Views())-1]; top != v {
+				t.Errorf("Expected the cover on top, got %q", top.Name())
+			}
+
+			if err := l.HideItem("b", LayoutVisible); err != nil {
+				t.Fatalf("HideItem failed: %v", err)
+			}
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			if viewExists(g, allHiddenView) {
+				t.Errorf("Expected the cover to be removed")
+			}
+		})
+	}
+}
+
+func TestAllHiddenWithModal(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", Hidden()),
+	)
+	l.SetAllHidden(AllHiddenPlaceholder)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if !viewExists(g, allHiddenView) {
+		t.Fatalf("Expected the cover view")
+	}
+
+	// A modal with items displayed keeps the cover of the layout below it.
+	l.ShowModal(g, NewLevel(LayoutVertical, NewRatioItem(1, "dialog")), 20, 5)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if !viewExists(g, allHiddenView) || !viewExists(g, "dialog") {
+		t.Errorf("Expected both the cover and the modal")
+	}
+}
+
+func TestAllHiddenUnset(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", Hidden()),
+		NewRatioItem(1, "b", Hidden()),
+	)
+	l.ShowModal(g, NewLevel(LayoutVertical, NewRatioItem(1, "dialog", Hidden())), 20, 5)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	var names []string
+	for _, v := range g.Views() {
+		names = append(names, v.Name())
+	}
+	if len(names) != 3 || viewExists(g, allHiddenView) {
+		t.Errorf("Unexpected views without a policy: %v", names)
+	}
+}
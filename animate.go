@@ -0,0 +1,179 @@
+package layout
+
+import (
+	"math"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// animationFrame is the time between two frames of an animation.
+const animationFrame = 30 * time.Millisecond
+
+// Easing maps the fraction of an animation's duration elapsed, from 0 to 1, to
+// the fraction of the way the views have moved.
+type Easing func(t float64) float64
+
+// EaseLinear moves views at a constant speed.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseOut moves views quickly at first, slowing down as they get there.
+func EaseOut(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOut moves views slowly at first and last, and quickly in between.
+func EaseInOut(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// animation is the move of an item's view from one rectangle to another.
+type animation struct {
+	from, to Rect
+	start    time.Time
+
+	// Where the view was last placed, or the zero Rect while the item isn't
+	// displayed.
+	at Rect
+
+	// Whether the item itself was hidden, so that it slides in once shown,
+	// see SlideToggled.
+	hidden bool
+}
+
+// AnimateResize sets the views of the layout to move to where they go over d,
+// rather than all at once, when their items are moved or resized, such as by
+// hiding or zooming another item, following easing, or EaseLinear if nil. The
+// layout is rendered again every few milliseconds, using the gui's Update,
+// until the views have arrived. Items that weren't displayed appear directly
+// where they go. A d of 0 turns animations off.
+func (l *layoutLevel) AnimateResize(d time.Duration, easing Easing) {
+	if easing == nil {
+		easing = EaseLinear
+	}
+	l.animate = d
+	l.easing = easing
+	if l.now == nil {
+		l.now = time.Now
+	}
+}
+
+// SlideToggled sets items shown or hidden, with HideItem or ToggleItem, to
+// slide in and out of view, growing from nothing along their level's
+// direction, or shrinking down to nothing, as the rest of their level makes
+// way, rather than appear or disappear at once. Items at the end of their
+// level slide from and to its end, the others from and to their start. The
+// duration and easing are those set with AnimateResize. Only items displayed
+// as views slide; the views within a level shown or hidden appear and
+// disappear at once.
+func (l *layoutLevel) SlideToggled(enabled bool) {
+	l.slide = enabled
+}
+
+// collapse returns r shrunk as far as a view allows along the direction of
+// the level it is in, which is in lr, against the end of lr if it touches
+// it, or its start otherwise.
+func collapse(r, lr Rect, direction LayoutDirection) Rect {
+	if direction == LayoutHorizontal {
+		if r.X1 == lr.X1 && r.X0 != lr.X0 {
+			return Rect{r.X1 - 1, r.Y0, r.X1, r.Y1}
+		}
+		return Rect{r.X0, r.Y0, r.X0 + 1, r.Y1}
+	}
+	if r.Y1 == lr.Y1 && r.Y0 != lr.Y0 {
+		return Rect{r.X0, r.Y1 - 1, r.X1, r.Y1}
+	}
+	return Rect{r.X0, r.Y0, r.X1, r.Y0 + 1}
+}
+
+// place returns where the item's view goes for this render, on its way to r,
+// noting in the pass if it hasn't arrived yet.
+func (l *layoutItem) place(r Rect, p *pass) Rect {
+	if p.animate == 0 {
+		l.anim = animation{}
+		return r
+	}
+	if !l.displayed {
+		return r
+	}
+
+	a := &l.anim
+	if a.at == (Rect{}) {
+		a.from, a.to, a.at = r, r, r
+		return r
+	}
+	if r != a.to {
+		a.from, a.to, a.start = a.at, r, p.now
+	}
+	return a.step(p)
+}
+
+// step moves the view along for this render, returning where it goes.
+func (a *animation) step(p *pass) Rect {
+	if a.at == a.to {
+		return a.at
+	}
+
+	t := float64(p.now.Sub(a.start)) / float64(p.animate)
+	if t >= 1 {
+		a.at = a.to
+		return a.at
+	}
+	e := p.easing(t)
+	between := func(from, to int) int {
+		return from + int(math.Round(float64(to-from)*e))
+	}
+	a.at = Rect{
+		between(a.from.X0, a.to.X0), between(a.from.Y0, a.to.Y0),
+		between(a.from.X1, a.to.X1), between(a.from.Y1, a.to.Y1),
+	}
+	p.animating = true
+	return a.at
+}
+
+// slideIn starts the view of the item, shown again after being hidden, from
+// nothing, if it is to slide in. The item is to go in r, within lr.
+func (l *layoutItem) slideIn(r, lr Rect, direction LayoutDirection, p *pass) {
+	a := &l.anim
+	if !p.slide || p.animate == 0 || !a.hidden {
+		return
+	}
+	a.hidden = false
+	a.at = collapse(r, lr, direction)
+}
+
+// slideOut moves the view of the item being hidden toward nothing, in its
+// level's rectangle lr, if it is to slide out. It returns false once the
+// view is gone, or if it doesn't slide, for the item to be hidden.
+func (l *layoutItem) slideOut(g *gocui.Gui, lr Rect, direction LayoutDirection, p *pass) (bool, error) {
+	a := &l.anim
+	if !p.slide || p.animate == 0 || !bool(l.hidden) || a.at == (Rect{}) {
+		return false, nil
+	}
+	if to := collapse(l.rect, lr, direction); to != a.to {
+		a.from, a.to, a.start = a.at, to, p.now
+	}
+	at := a.step(p)
+	if at == a.to {
+		return false, nil
+	}
+	_, err := g.SetView(l.name, at.X0, at.Y0, at.X1, at.Y1, 0)
+	return true, err
+}
+
+// nextFrame renders the layout again after a frame, if views are still on
+// their way. Only one frame is pending at a time.
+func (l *layoutLevel) nextFrame(g *gocui.Gui, p *pass) {
+	if !p.animating || l.framePending {
+		return
+	}
+	l.framePending = true
+	time.AfterFunc(animationFrame, func() {
+		g.Update(func(*gocui.Gui) error {
+			l.framePending = false
+			return nil
+		})
+	})
+}
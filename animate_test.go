@@ -0,0 +1,117 @@
+package layout
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnimateResize(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+	)
+	l.AnimateResize(100*time.Millisecond, nil)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	render := func() {
+		t.Helper()
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+
+	// Views appear directly where they go.
+	render()
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 39, 24},
+		"b": {40, 0, 79, 24},
+	})
+	if l.framePending {
+		t.Errorf("Unexpected frame scheduled")
+	}
+
+	if err := l.ResizeItem("a", 0, 60); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	for _, tc := range []struct {
+		at   time.Duration
+		want map[string]size
+	}{
+		{0, map[string]size{"a": {0, 0, 39, 24}, "b": {40, 0, 79, 24}}},
+		{50 * time.Millisecond, map[string]size{"a": {0, 0, 49, 24}, "b": {50, 0, 79, 24}}},
+		{100 * time.Millisecond, map[string]size{"a": {0, 0, 59, 24}, "b": {60, 0, 79, 24}}},
+	} {
+		now = now.Add(tc.at)
+		render()
+		checkViews(t, g, tc.want)
+		now = now.Add(-tc.at)
+	}
+	if !l.framePending {
+		t.Errorf("Expected the next frame to be scheduled")
+	}
+
+	// Turned off, views go straight to where they go.
+	l.framePending = false
+	l.AnimateResize(0, nil)
+	if err := l.ResizeItem("a", 0, 20); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	render()
+	checkViews(t, g, map[string]size{"a": {0, 0, 19, 24}, "b": {20, 0, 79, 24}})
+	if l.framePending {
+		t.Errorf("Unexpected frame scheduled")
+	}
+}
+
+func TestEasing(t *testing.T) {
+	for name, f := range map[string]Easing{
+		"EaseLinear": EaseLinear,
+		"EaseOut":    EaseOut,
+		"EaseInOut":  EaseInOut,
+	} {
+		if f(0) != 0 || f(1) != 1 {
+			t.Errorf("%s: expected to go from 0 to 1, got %v to %v", name, f(0), f(1))
+		}
+	}
+}
+
+func TestSlideToggled(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "main"),
+		NewFixedItem(20, "side"),
+	)
+	l.AnimateResize(100*time.Millisecond, EaseLinear)
+	l.SlideToggled(true)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	render := func(after time.Duration, want map[string]size) {
+		t.Helper()
+		now = now.Add(after)
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+		checkViews(t, g, want)
+	}
+	render(0, map[string]size{"main": {0, 0, 59, 24}, "side": {60, 0, 79, 24}})
+
+	// Hidden, the item shrinks to the end of the level.
+	if err := l.ToggleItem("side"); err != nil {
+		t.Fatalf("ToggleItem failed: %v", err)
+	}
+	render(0, map[string]size{"main": {0, 0, 59, 24}, "side": {60, 0, 79, 24}})
+	render(50*time.Millisecond, map[string]size{"main": {0, 0, 69, 24}, "side": {69, 0, 79, 24}})
+	render(50*time.Millisecond, map[string]size{"main": {0, 0, 79, 24}})
+	if got := l.VisibleItems(); len(got) != 1 {
+		t.Errorf("Expected only main to be visible, got %v", got)
+	}
+
+	// Shown again, it grows back from there.
+	if err := l.ToggleItem("side"); err != nil {
+		t.Fatalf("ToggleItem failed: %v", err)
+	}
+	render(0, map[string]size{"main": {0, 0, 79, 24}, "side": {78, 0, 79, 24}})
+	render(50*time.Millisecond, map[string]size{"main": {0, 0, 69, 24}, "side": {69, 0, 79, 24}})
+	render(50*time.Millisecond, map[string]size{"main": {0, 0, 59, 24}, "side": {60, 0, 79, 24}})
+}
@@ -0,0 +1,81 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// DiscrepancyKind is the kind of mismatch found by Audit.
+type DiscrepancyKind int
+
+const (
+	// ExtraView is a view created by the layout for an item that is no
+	// longer part of it. See Prune.
+	ExtraView DiscrepancyKind = iota
+	// MissingView is a displayed item that has no view.
+	MissingView
+	// MovedView is a view that isn't where the layout placed it.
+	MovedView
+)
+
+// Discrepancy is a mismatch between the layout and the views of the gui.
+type Discrepancy struct {
+	Kind DiscrepancyKind
+	// The name of the view.
+	View string
+	// Where the layout placed the view, and where it is, for MovedView.
+	Want, Got Rect
+}
+
+func (d Discrepancy) String() string {
+	switch d.Kind {
+	case ExtraView:
+		return fmt.Sprintf("view '%s' is not in the layout", d.View)
+	case MissingView:
+		return fmt.Sprintf("view '%s' is missing", d.View)
+	case MovedView:
+		return fmt.Sprintf("view '%s' is at (%d,%d)-(%d,%d), not (%d,%d)-(%d,%d)", d.View,
+			d.Got.X0, d.Got.Y0, d.Got.X1, d.Got.Y1, d.Want.X0, d.Want.Y0, d.Want.X1, d.Want.Y1)
+	}
+	return fmt.Sprintf("view '%s': unknown discrepancy %d", d.View, d.Kind)
+}
+
+// Audit compares the layout, as last rendered, with the views of the gui, and
+// returns the differences found: views the layout created that no longer
+// belong to it, items it displays whose views are missing, and views that
+// were moved or resized since. Views the layout never created are ignored, so
+// that it can be mixed with views managed by hand.
+func (l *layoutLevel) Audit(g *gocui.Gui) []Discrepancy {
+	var ds []Discrepancy
+
+	l.visibleViews(func(item *layoutItem) {
+		v, err := g.View(item.name)
+		if err != nil {
+			ds = append(ds, Discrepancy{Kind: MissingView, View: item.name})
+			return
+		}
+		x0, y0, x1, y1 := v.Dimensions()
+		if got := (Rect{x0, y0, x1, y1}); got != item.rect {
+			ds = append(ds, Discrepancy{Kind: MovedView, View: item.name, Want: item.rect, Got: got})
+		}
+	})
+
+	keep := l.keep()
+	var extra []string
+	for name := range l.created {
+		if keep[name] {
+			continue
+		}
+		if _, err := g.View(name); err == nil {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		ds = append(ds, Discrepancy{Kind: ExtraView, View: name})
+	}
+
+	return ds
+}
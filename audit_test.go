@@ -0,0 +1,49 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestAudit(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "c"),
+		NewRatioItem(1, "d"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.SetView("mine", 0, 0, 5, 5, 0); err != nil && err != gocui.ErrUnknownView {
+		t.Fatalf("SetView failed: %v", err)
+	}
+	if ds := l.Audit(g); len(ds) != 0 {
+		t.Errorf("Unexpected discrepancies: %v", ds)
+	}
+
+	if _, err := g.SetView("a", 0, 0, 10, 10, 0); err != nil {
+		t.Fatalf("SetView failed: %v", err)
+	}
+	if err := g.DeleteView("b"); err != nil {
+		t.Fatalf("DeleteView failed: %v", err)
+	}
+	if err := l.RemoveItem("c"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+
+	want := []Discrepancy{
+		{Kind: MovedView, View: "a", Want: Rect{0, 0, 19, 24}, Got: Rect{0, 0, 10, 10}},
+		{Kind: MissingView, View: "b"},
+		{Kind: ExtraView, View: "c"},
+	}
+	if got := l.Audit(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected discrepancies:\n got %v\nwant %v", got, want)
+	}
+	if got, want := want[0].String(), "view 'a' is at (0,0)-(10,10), not (0,0)-(19,24)"; got != want {
+		t.Errorf("Unexpected description: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package layout
+
+import (
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// ShowFor shows the named item, and hides it again after d, such as for a
+// status or volume popup. The layout is redrawn once it is hidden. Showing
+// the item again with ShowFor before then restarts the wait, and
+// CancelShowFor keeps it displayed. Hiding it can still be vetoed, see
+// BeforeHide, in which case it stays displayed.
+func (l *layoutLevel) ShowFor(g *gocui.Gui, name string, d time.Duration) error {
+	if err := l.HideItem(name, LayoutVisible); err != nil {
+		return err
+	}
+
+	l.CancelShowFor(name)
+	var t *time.Timer
+	t = time.AfterFunc(d, func() {
+		g.Update(func(*gocui.Gui) error {
+			// Canceled or restarted since.
+			if l.showTimers[name] != t {
+				return nil
+			}
+			delete(l.showTimers, name)
+			// The item may be gone, or be kept by BeforeHide, neither of
+			// which should stop the gui.
+			l.HideItem(name, LayoutHidden)
+			return nil
+		})
+	})
+	if l.showTimers == nil {
+		l.showTimers = make(map[string]*time.Timer)
+	}
+	l.showTimers[name] = t
+
+	return nil
+}
+
+// CancelShowFor keeps the named item displayed, rather than hiding it once
+// the time given to ShowFor is up.
+func (l *layoutLevel) CancelShowFor(name string) {
+	if t, ok := l.showTimers[name]; ok {
+		t.Stop()
+		delete(l.showTimers, name)
+	}
+}
@@ -0,0 +1,63 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestShowFor(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewRatioItem(1, "main"),
+		NewFixedItem(3, "popup", Hidden()),
+		NewFixedItem(3, "status", Hidden()),
+	)
+	if err := l.ShowFor(g, "missing", time.Second); !errors.Is(err, NotFound) {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	// Canceled, the item stays displayed.
+	if err := l.ShowFor(g, "status", 10*time.Millisecond); err != nil {
+		t.Fatalf("ShowFor failed: %v", err)
+	}
+	l.CancelShowFor("status")
+	if len(l.showTimers) != 0 {
+		t.Errorf("Expected the timer to be canceled")
+	}
+
+	if err := l.ShowFor(g, "popup", 10*time.Millisecond); err != nil {
+		t.Fatalf("ShowFor failed: %v", err)
+	}
+	visible := make(chan []string, 10)
+	l.AfterLayout(func(s Summary) {
+		visible <- s.Visible
+	})
+	g.SetManager(l)
+	done := make(chan error, 1)
+	go func() {
+		done <- g.MainLoop()
+	}()
+
+	var got []string
+	timeout := time.After(time.Second)
+	for len(got) != 2 {
+		select {
+		case got = <-visible:
+		case <-timeout:
+			t.Fatalf("Expected the popup to be hidden, got %v", got)
+		}
+	}
+	if got[0] != "main" || got[1] != "status" {
+		t.Errorf("Unexpected visible items: %v", got)
+	}
+
+	g.Update(func(*gocui.Gui) error {
+		return gocui.ErrQuit
+	})
+	if err := <-done; err != gocui.ErrQuit {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package layout
+
+// Begin starts a batch of changes to the layout. Until the matching Commit,
+// rendering the layout leaves the screen as it was, so that the intermediate
+// states of several changes are never displayed. Batches can be nested.
+func (l *layoutLevel) Begin() {
+	if l.batch == 0 {
+		l.batchRecorded = false
+	}
+	l.batch++
+}
+
+// Commit ends a batch of changes started by Begin. Once all batches are
+// committed, the next render displays all the changes at once.
+func (l *layoutLevel) Commit() {
+	if l.batch > 0 {
+		l.batch--
+	}
+}
+
+// Mutate applies the changes made by f as a single batch. As with any change,
+// calls from outside gocui's main loop should be made through the gui's
+// Update method.
+func (l *layoutLevel) Mutate(f func(l *layoutLevel) error) error {
+	l.Begin()
+	defer l.Commit()
+
+	return f(l)
+}
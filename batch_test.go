@@ -0,0 +1,52 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "c"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	l.Begin()
+	if err := l.HideItem("a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 25, 24},
+		"b": {26, 0, 51, 24},
+		"c": {52, 0, 79, 24},
+	})
+
+	err := l.Mutate(func(l *layoutLevel) error {
+		return l.ResizeItem("b", 0, 20)
+	})
+	if err != nil {
+		t.Fatalf("Mutate failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {26, 0, 51, 24},
+	})
+
+	l.Commit()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {0, 0, 19, 24},
+		"c": {20, 0, 79, 24},
+	})
+}
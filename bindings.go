@@ -0,0 +1,208 @@
+package layout
+
+import (
+	"sort"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Action is a command bound to a key by BindDefaults.
+type Action int
+
+const (
+	// ActionFocusNext focuses the next view, see FocusNext.
+	ActionFocusNext Action = iota
+	// ActionFocusPrev focuses the previous view, see FocusPrev.
+	ActionFocusPrev
+	// ActionZoom zooms the focused item, or unzooms the layout if zoomed.
+	ActionZoom
+	// ActionEqualize equalizes the level of the focused item.
+	ActionEqualize
+	// ActionResize enters resize mode, in which the arrow keys grow and
+	// shrink the focused item, until Enter or Esc is pressed.
+	ActionResize
+)
+
+// Binding is a key, with its modifier, as passed to gocui's SetKeybinding.
+type Binding struct {
+	Key interface{}
+	Mod gocui.Modifier
+}
+
+// DefaultBindings are the keys bound by BindDefaults, unless remapped.
+var DefaultBindings = map[Action]Binding{
+	ActionFocusNext: {gocui.KeyTab, gocui.ModNone},
+	ActionFocusPrev: {gocui.KeyTab, gocui.ModAlt},
+	ActionZoom:      {'z', gocui.ModAlt},
+	ActionEqualize:  {'=', gocui.ModAlt},
+	ActionResize:    {'r', gocui.ModAlt},
+}
+
+// resizeView is the name of the view holding the focus in resize mode.
+const resizeView = "_layoutResize"
+
+type bindConfig struct {
+	keys    map[Action]Binding
+	toggles map[string]Binding
+}
+
+// BindOption changes the keys bound by BindDefaults.
+type BindOption func(*bindConfig)
+
+// BindKey binds the action to another key. A nil key leaves it unbound.
+func BindKey(action Action, key interface{}, mod gocui.Modifier) BindOption {
+	return func(c *bindConfig) {
+		if key == nil {
+			delete(c.keys, action)
+			return
+		}
+		c.keys[action] = Binding{key, mod}
+	}
+}
+
+// BindToggle binds a key to show and hide the named item.
+func BindToggle(key interface{}, mod gocui.Modifier, name string) BindOption {
+	return func(c *bindConfig) {
+		c.toggles[name] = Binding{key, mod}
+	}
+}
+
+// BindDefaults binds keys, for all views, to common commands on the layout:
+// cycling the focus, zooming, equalizing and resizing. The keys are those of
+// DefaultBindings, which can be changed with BindKey, and keys to toggle
+// items can be added with BindToggle. Views with bindings of their own for
+// the same keys keep them.
+func (l *layoutLevel) BindDefaults(g *gocui.Gui, opts ...BindOption) error {
+	c := &bindConfig{
+		keys:    make(map[Action]Binding),
+		toggles: make(map[string]Binding),
+	}
+	for action, b := range DefaultBindings {
+		c.keys[action] = b
+	}
+	for _, o := range opts {
+		o(c)
+	}
+
+	handlers := map[Action]func(*gocui.Gui, *gocui.View) error{
+		ActionFocusNext: func(g *gocui.Gui, _ *gocui.View) error { return l.FocusNext(g) },
+		ActionFocusPrev: func(g *gocui.Gui, _ *gocui.View) error { return l.FocusPrev(g) },
+		ActionZoom:      func(g *gocui.Gui, _ *gocui.View) error { return l.ToggleZoom(g) },
+		ActionEqualize:  l.equalizeFocused,
+		ActionResize:    l.startResize,
+	}
+	// Bound in order, so that ShowHelp lists them that way.
+	for action := ActionFocusNext; action <= ActionResize; action++ {
+		b, ok := c.keys[action]
+		if !ok {
+			continue
+		}
+		if err := l.bindHelp(g, b.Key, b.Mod, actionHelp[action], handlers[action]); err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(c.toggles))
+	for name := range c.toggles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		name, b := name, c.toggles[name]
+		if err := l.bindHelp(g, b.Key, b.Mod, "Show or hide "+name, func(*gocui.Gui, *gocui.View) error {
+			return l.ToggleItem(name)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// focusedItem returns the name of the item of the gui's current view, if it
+// is part of the layout.
+func (l *layoutLevel) focusedItem(g *gocui.Gui) (string, bool) {
+	v := g.CurrentView()
+	if v == nil {
+		return "", false
+	}
+	if _, err := l.findItem(v.Name()); err != nil {
+		return "", false
+	}
+	return v.Name(), true
+}
+
+func (l *layoutLevel) equalizeFocused(g *gocui.Gui, _ *gocui.View) error {
+	name, ok := l.focusedItem(g)
+	if !ok {
+		return nil
+	}
+	parent, err := l.ParentOf(name)
+	if err != nil {
+		return err
+	}
+	return l.Equalize(parent)
+}
+
+// startResize gives the focus to an offscreen view, whose bindings resize the
+// item that had the focus until resize mode is left.
+func (l *layoutLevel) startResize(g *gocui.Gui, _ *gocui.View) error {
+	name, ok := l.focusedItem(g)
+	if !ok {
+		return nil
+	}
+
+	if _, err := g.SetView(resizeView, -3, -3, -1, -1, 0); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	if _, err := g.SetCurrentView(resizeView); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings(resizeView)
+	arrows := map[gocui.Key]Direction{
+		gocui.KeyArrowLeft:  Left,
+		gocui.KeyArrowRight: Right,
+		gocui.KeyArrowUp:    Up,
+		gocui.KeyArrowDown:  Down,
+	}
+	for key, dir := range arrows {
+		dir := dir
+		if err := g.SetKeybinding(resizeView, key, gocui.ModNone, func(*gocui.Gui, *gocui.View) error {
+			return l.resizeToward(name, dir)
+		}); err != nil {
+			return err
+		}
+	}
+	done := func(g *gocui.Gui, _ *gocui.View) error {
+		g.DeleteKeybindings(resizeView)
+		if err := g.DeleteView(resizeView); err != nil {
+			return err
+		}
+		_, err := g.SetCurrentView(name)
+		return err
+	}
+	for _, key := range []gocui.Key{gocui.KeyEnter, gocui.KeyEsc} {
+		if err := g.SetKeybinding(resizeView, key, gocui.ModNone, done); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resizeToward grows the named item by one line or column in the given
+// direction, or shrinks it for Left and Up. If the item's level isn't along
+// that direction, the closest enclosing item that is resizes instead.
+func (l *layoutLevel) resizeToward(name string, dir Direction) error {
+	levels, idx, err := l.findPath(name)
+	if err != nil {
+		return err
+	}
+
+	for k := len(levels) - 1; k >= 0; k-- {
+		if levels[k].direction == dir.axis() {
+			return l.GrowItem(levels[k].items[idx[k]].name, dir.step())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestBindDefaults(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(3, "c"),
+		))),
+	)
+	if err := l.BindDefaults(g,
+		BindKey(ActionEqualize, nil, gocui.ModNone),
+		BindToggle(gocui.KeyF2, gocui.ModNone, "a"),
+	); err != nil {
+		t.Fatalf("BindDefaults failed: %v", err)
+	}
+	if err := l.Focus("c"); err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.ToggleZoom(g); err != nil {
+		t.Fatalf("ToggleZoom failed: %v", err)
+	}
+	if got := l.Zoomed(); got != "c" {
+		t.Errorf("Expected c to be zoomed, got %q", got)
+	}
+	if err := l.ToggleZoom(g); err != nil {
+		t.Fatalf("ToggleZoom failed: %v", err)
+	}
+	if got := l.Zoomed(); got != "" {
+		t.Errorf("Expected nothing to be zoomed, got %q", got)
+	}
+
+	if err := l.equalizeFocused(g, nil); err != nil {
+		t.Fatalf("equalizeFocused failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {40, 0, 79, 11},
+		"c": {40, 12, 79, 24},
+	})
+}
+
+func TestResizeMode(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+	if err := l.Focus("b"); err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.startResize(g, nil); err != nil {
+		t.Fatalf("startResize failed: %v", err)
+	}
+	if v := g.CurrentView(); v == nil || v.Name() != resizeView {
+		t.Fatalf("Expected resize mode, got %v", v)
+	}
+
+	// b's own level is vertical, so left and right resize its container.
+	for _, dir := range []Direction{Down, Down, Left} {
+		if err := l.resizeToward("b", dir); err != nil {
+			t.Fatalf("resizeToward failed: %v", err)
+		}
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 40, 24},
+		"b": {41, 0, 79, 13},
+		"c": {41, 14, 79, 24},
+	})
+}
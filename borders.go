@@ -0,0 +1,248 @@
+package layout
+
+import (
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// doubleClick is the longest time between the two clicks of a double-click.
+const doubleClick = 400 * time.Millisecond
+
+// EqualizeScope is what double-clicking a border resets, see
+// SetDoubleClickEqualize.
+type EqualizeScope int
+
+const (
+	// EqualizePair gives the two items either side of the border the same
+	// size.
+	EqualizePair EqualizeScope = iota
+	// EqualizeLevel gives all the ratio items of the border's level the same
+	// weight, as Equalize does.
+	EqualizeLevel
+)
+
+// EnableDragBorders allows the borders between any two items displayed next
+// to each other to be dragged with the mouse, the same as with the border of a
+// splitter once EnableDrag was called: clicking on a border picks it up, and
+// it follows the mouse until the next click. Scrolling the mouse wheel over a
+// border moves it one line or column at a time, up or left for wheel up. The
+// items' sizes are changed to match, so the new sizes are kept as the screen
+// is resized, and the whole drag can be undone. The gui must have Mouse
+// enabled. Splitters are left to EnableDrag.
+func (l *layoutLevel) EnableDragBorders(enabled bool) {
+	l.dragBorders = enabled
+	l.changed()
+}
+
+// SetDoubleClickEqualize sets what double-clicking a draggable border resets,
+// the two items either side of it by default, or all the items of its level.
+func (l *layoutLevel) SetDoubleClickEqualize(scope EqualizeScope) {
+	l.equalizeScope = scope
+}
+
+// sashName returns the name of the view over the border after the item.
+func sashName(item *layoutItem) string {
+	return item.name + "~sash"
+}
+
+// layoutSashes places invisible views over the borders between the displayed
+// items of the level, to receive the clicks that start a drag, and deletes
+// those no longer needed.
+func (l *layoutLevel) layoutSashes(g *gocui.Gui, p *pass, forceHidden HideLayout) error {
+	enabled := p.dragBorders && p.zoomed == nil && !bool(forceHidden) && l.splitter == nil
+	if !enabled && l.sashes == nil {
+		return nil
+	}
+
+	want := make(map[string]*layoutItem)
+	if enabled {
+		var prev *layoutItem
+		for idx, item := range l.items {
+			if l.hidden(idx) {
+				continue
+			}
+			if prev != nil {
+				want[sashName(prev)] = prev
+				if err := l.layoutSash(g, p.root, prev); err != nil {
+					return err
+				}
+			}
+			prev = item
+		}
+	}
+
+	for name := range l.sashes {
+		if _, ok := want[name]; ok {
+			continue
+		}
+		g.DeleteKeybindings(name)
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	l.sashes = want
+	if len(want) == 0 {
+		l.sashes = nil
+	}
+
+	return nil
+}
+
+// layoutSash places the view over the border after the item. Drags and
+// scrolls are recorded by root.
+func (l *layoutLevel) layoutSash(g *gocui.Gui, root *layoutLevel, item *layoutItem) error {
+	r := item.rect
+	x0, y0, x1, y1 := r.X1-1, l.rect.Y0, r.X1+1, l.rect.Y1
+	if l.direction == LayoutVertical {
+		x0, y0, x1, y1 = l.rect.X0, r.Y1-1, l.rect.X1, r.Y1+1
+	}
+
+	name := sashName(item)
+	v, err := g.SetView(name, x0, y0, x1, y1, 0)
+	if err == gocui.ErrUnknownView {
+		v.Visible = false
+		v.Frame = false
+		if err := g.SetKeybinding(name, gocui.MouseLeft, gocui.ModNone,
+			func(g *gocui.Gui, v *gocui.View) error {
+				return l.startBorderDrag(g, root, item)
+			}); err != nil {
+			return err
+		}
+		if err := g.SetKeybinding(name, gocui.MouseWheelUp, gocui.ModNone,
+			func(g *gocui.Gui, v *gocui.View) error {
+				l.nudgeBorder(root, item, -1)
+				return nil
+			}); err != nil {
+			return err
+		}
+		return g.SetKeybinding(name, gocui.MouseWheelDown, gocui.ModNone,
+			func(g *gocui.Gui, v *gocui.View) error {
+				l.nudgeBorder(root, item, 1)
+				return nil
+			})
+	}
+	return err
+}
+
+// nudgeBorder moves the border after the item by delta, recording the state
+// before the change in root.
+func (l *layoutLevel) nudgeBorder(root *layoutLevel, item *layoutItem, delta int) {
+	a, b := l.besides(item)
+	if a == nil || b == nil {
+		return
+	}
+
+	root.record()
+	l.resizePair(a, b, a.size+delta)
+}
+
+// startBorderDrag picks up the border after the item, recording the state
+// before the drag in root. Clicking again quickly enough to be a double-click
+// equalizes the items instead.
+func (l *layoutLevel) startBorderDrag(g *gocui.Gui, root *layoutLevel, item *layoutItem) error {
+	root.record()
+	drag := func(x, y int) error {
+		return l.dragBorder(item, x, y)
+	}
+	return startCapture(g, drag, doubleClicked(drag, func() error {
+		l.equalizeBorder(root.equalizeScope, item)
+		return nil
+	}))
+}
+
+// doubleClicked returns a drop handler calling onDouble instead of onDrop when
+// the drop follows the pick up closely enough to be a double-click.
+func doubleClicked(onDrop func(x, y int) error, onDouble func() error) func(x, y int) error {
+	start := time.Now()
+	return func(x, y int) error {
+		if time.Since(start) < doubleClick {
+			return onDouble()
+		}
+		return onDrop(x, y)
+	}
+}
+
+// dragBorder moves the border after the item to the given position, resizing
+// the item and the next displayed one, and keeping the rest as they are.
+func (l *layoutLevel) dragBorder(item *layoutItem, x, y int) error {
+	a, b := l.besides(item)
+	if a == nil || b == nil {
+		return nil
+	}
+
+	pos, end := x, a.rect.X1
+	if l.direction == LayoutVertical {
+		pos, end = y, a.rect.Y1
+	}
+	l.resizePair(a, b, a.size+pos-end)
+
+	return nil
+}
+
+// equalizeBorder resets the items either side of the border after the item,
+// or all the items of the level, depending on scope.
+func (l *layoutLevel) equalizeBorder(scope EqualizeScope, item *layoutItem) {
+	if scope == EqualizeLevel {
+		l.equalize()
+		return
+	}
+
+	a, b := l.besides(item)
+	if a == nil || b == nil {
+		return
+	}
+	l.resizePair(a, b, (a.size+b.size)/2)
+}
+
+// resizePair gives a, and the displayed item b after it, sizes summing to
+// what they have now, with size going to a as far as possible.
+func (l *layoutLevel) resizePair(a, b *layoutItem, size int) {
+	total := a.size + b.size
+	if total < 4 {
+		return
+	}
+	if size < 2 {
+		size = 2
+	} else if size > total-2 {
+		size = total - 2
+	}
+
+	if a.ratio > 0 || b.ratio > 0 {
+		// Weigh all ratio items by their sizes, so the others keep theirs,
+		// in proportion so that they still fit once the space shrinks.
+		l.weighBySizes()
+	}
+	if a.fixed > 0 {
+		a.fixed = size
+	} else {
+		a.ratio = size
+	}
+	if b.fixed > 0 {
+		b.fixed = total - size
+	} else {
+		b.ratio = total - size
+	}
+	if l.proportional {
+		l.reduceWeights()
+	}
+	l.changed()
+}
+
+// besides returns the item, if it is displayed, and the next displayed item
+// after it in the level.
+func (l *layoutLevel) besides(item *layoutItem) (*layoutItem, *layoutItem) {
+	var a *layoutItem
+	for idx, it := range l.items {
+		if l.hidden(idx) {
+			continue
+		}
+		if a != nil {
+			return a, it
+		}
+		if it == item {
+			a = item
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,301 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDragBorders(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewFixedItem(20, "c"),
+		NewRatioItem(2, "d"),
+	)
+	l.EnableDragBorders(true)
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a":      {0, 0, 14, 24},
+		"b":      {15, 0, 29, 24},
+		"c":      {30, 0, 49, 24},
+		"d":      {50, 0, 79, 24},
+		"a~sash": {13, 0, 15, 24},
+		"b~sash": {28, 0, 30, 24},
+		"c~sash": {48, 0, 50, 24},
+	})
+
+	// Between two ratio items, the rest keep their size.
+	if err := l.dragBorder(l.items[0], 9, 5); err != nil {
+		t.Fatalf("dragBorder failed: %v", err)
+	}
+	// Sizes are weighed as rendered, which they are after every move.
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	// Between a fixed and a ratio item, the fixed item changes.
+	if err := l.dragBorder(l.items[2], 59, 5); err != nil {
+		t.Fatalf("dragBorder failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 9, 24},
+		"b": {10, 0, 29, 24},
+		"c": {30, 0, 59, 24},
+		"d": {60, 0, 79, 24},
+	})
+	if got := l.items[2].fixed; got != 30 {
+		t.Errorf("Unexpected fixed size after drag: got %d, want 30", got)
+	}
+
+	// A border can't be dragged past the next one.
+	if err := l.dragBorder(l.items[0], 70, 5); err != nil {
+		t.Fatalf("dragBorder failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 27, 24},
+		"b": {28, 0, 29, 24},
+	})
+
+	// Undo reverts the whole drag.
+	if err := l.startBorderDrag(g, l, l.items[0]); err != nil {
+		t.Fatalf("startBorderDrag failed: %v", err)
+	}
+	for _, x := range []int{20, 15, 5} {
+		if err := l.dragBorder(l.items[0], x, 5); err != nil {
+			t.Fatalf("dragBorder failed: %v", err)
+		}
+	}
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 27, 24},
+		"b": {28, 0, 29, 24},
+	})
+
+	if err := l.HideItem("b", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if !viewExists(g, "a~sash") || !viewExists(g, "c~sash") {
+		t.Errorf("Missing sashes with b hidden")
+	}
+	if viewExists(g, "b~sash") {
+		t.Errorf("Sash of hidden item still exists")
+	}
+
+	l.EnableDragBorders(false)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	for _, name := range []string{"a~sash", "c~sash"} {
+		if viewExists(g, name) {
+			t.Errorf("Sash %q still exists after disabling", name)
+		}
+	}
+}
+
+func TestDragBorderThenShrink(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewFixedItem(20, "c"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.dragBorder(l.items[0], 40, 5); err != nil {
+		t.Fatalf("dragBorder failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		w    int
+		want map[string]Rect
+	}{
+		{80, map[string]Rect{"a": {0, 0, 40, 24}, "b": {41, 0, 59, 24}, "c": {60, 0, 79, 24}}},
+		{79, map[string]Rect{"a": {0, 0, 39, 24}, "b": {40, 0, 58, 24}, "c": {59, 0, 78, 24}}},
+		{40, map[string]Rect{"a": {0, 0, 12, 24}, "b": {13, 0, 19, 24}, "c": {20, 0, 39, 24}}},
+	} {
+		got, err := l.Compute(tc.w, 25)
+		if err != nil {
+			t.Errorf("Compute(%d) failed: %v", tc.w, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Compute(%d): got %v, want %v", tc.w, got, tc.want)
+		}
+	}
+}
+
+func TestDragBordersEqualize(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(3, "b"),
+		NewFixedItem(20, "c"),
+		NewRatioItem(2, "d"),
+	)
+	l.EnableDragBorders(true)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	// A drop soon after the pick up is a double-click.
+	drop := doubleClicked(func(x, y int) error {
+		return l.dragBorder(l.items[0], x, y)
+	}, func() error {
+		l.equalizeBorder(EqualizePair, l.items[0])
+		return nil
+	})
+	if err := drop(5, 5); err != nil {
+		t.Fatalf("drop failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 19, 24},
+		"b": {20, 0, 39, 24},
+		"c": {40, 0, 59, 24},
+		"d": {60, 0, 79, 24},
+	})
+	// The level still fits a smaller screen.
+	got, err := l.Compute(71, 25)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	want := map[string]Rect{"a": {0, 0, 16, 24}, "b": {17, 0, 33, 24}, "c": {34, 0, 53, 24}, "d": {54, 0, 70, 24}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected rects: got %v, want %v", got, want)
+	}
+
+	l.equalizeBorder(EqualizeLevel, l.items[1])
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 19, 24},
+		"b": {20, 0, 39, 24},
+		"c": {40, 0, 59, 24},
+		"d": {60, 0, 79, 24},
+	})
+	for _, item := range l.items {
+		if item.fixed == 0 && item.ratio != 1 {
+			t.Errorf("Unexpected weight for %q: got %d, want 1", item.name, item.ratio)
+		}
+	}
+}
+
+func TestNudgeBorders(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewRatioItem(1, "top"),
+		NewFixedItem(5, "middle"),
+		NewRatioItem(1, "bottom"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	l.nudgeBorder(l, l.items[0], 1)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	l.nudgeBorder(l, l.items[1], -1)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"top":    {0, 0, 79, 10},
+		"middle": {0, 11, 79, 13},
+		"bottom": {0, 14, 79, 24},
+	})
+
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"top":    {0, 0, 79, 10},
+		"middle": {0, 11, 79, 14},
+		"bottom": {0, 15, 79, 24},
+	})
+}
+
+func TestNudgeBorderThenShrink(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	l.nudgeBorder(l, l.items[0], 1)
+
+	got, err := l.Compute(79, 25)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	want := map[string]Rect{"a": {0, 0, 39, 24}, "b": {40, 0, 78, 24}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected rects: got %v, want %v", got, want)
+	}
+}
+
+func TestFixedBorderKeepsRatios(t *testing.T) {
+	g := newTestGui(t, false)
+	newLevel := func() *layoutLevel {
+		l := NewLevel(LayoutHorizontal,
+			NewRatioItem(1, "a"),
+			NewRatioItem(1, "b"),
+			NewFixedItem(20, "c"),
+			NewRatioItem(2, "d"),
+		)
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+		return l
+	}
+
+	// Between a fixed and a ratio item, the other ratio items keep their
+	// size, from the first change on.
+	for _, tc := range []struct {
+		desc   string
+		change func(l *layoutLevel)
+		c, d   size
+	}{
+		{"drag", func(l *layoutLevel) { l.dragBorder(l.items[2], 59, 5) }, size{30, 0, 59, 24}, size{60, 0, 79, 24}},
+		{"nudge", func(l *layoutLevel) { l.nudgeBorder(l, l.items[2], 1) }, size{30, 0, 50, 24}, size{51, 0, 79, 24}},
+		{"equalize", func(l *layoutLevel) { l.equalizeBorder(EqualizePair, l.items[2]) }, size{30, 0, 54, 24}, size{55, 0, 79, 24}},
+	} {
+		l := newLevel()
+		tc.change(l)
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("%s: Layout failed: %v", tc.desc, err)
+		}
+		checkViews(t, g, map[string]size{
+			"a": {0, 0, 14, 24},
+			"b": {15, 0, 29, 24},
+			"c": tc.c,
+			"d": tc.d,
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Button is a control drawn on the top border of an item's view, see
+// WithButtons.
+type Button int
+
+const (
+	// ButtonCollapse collapses the item, or expands it back, see
+	// ToggleCollapse.
+	ButtonCollapse Button = iota
+	// ButtonZoom zooms the item, or unzooms the layout if it is zoomed.
+	ButtonZoom
+	// ButtonClose removes the item from the layout.
+	ButtonClose
+)
+
+// ButtonGlyphs are the characters drawn for each button.
+var ButtonGlyphs = map[Button]string{
+	ButtonCollapse: "_",
+	ButtonZoom:     "□",
+	ButtonClose:    "×",
+}
+
+// collapsedSize is the size of collapsed items, leaving the borders of their
+// views.
+const collapsedSize = 2
+
+// WithButtons draws the buttons, in order, at the right end of the top border
+// of the item's view, and handles clicks on them. The gui must have Mouse
+// enabled. Buttons are left out while the view is too narrow for them.
+func WithButtons(buttons ...Button) layoutItemOption {
+	return func(l *layoutItem) {
+		l.buttons = buttons
+	}
+}
+
+// ToggleCollapse shrinks the named item to the borders of its view, along its
+// level, or gives it back the size it had before. In a vertical level, the
+// title of a collapsed item is still displayed.
+func (l *layoutLevel) ToggleCollapse(name string) error {
+	i, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	l.record()
+	if i.collapsed != nil && i.fixed == collapsedSize {
+		i.ratio, i.fixed = i.collapsed[0], i.collapsed[1]
+		i.collapsed = nil
+		return nil
+	}
+	i.collapsed = &[2]int{i.ratio, i.fixed}
+	i.ratio, i.fixed = 0, collapsedSize
+
+	return nil
+}
+
+// buttonsName returns the name of the view holding the item's buttons.
+func buttonsName(item *layoutItem) string {
+	return item.name + "~buttons"
+}
+
+// layoutButtons places the buttons of the displayed items over their views,
+// and deletes those no longer needed.
+func (l *layoutLevel) layoutButtons(g *gocui.Gui, version uint64) error {
+	if l.buttonItems == nil || version != l.buttonsVersion {
+		l.buttonsVersion = version
+		l.buttonItems = []*layoutItem{}
+		l.visibleViews(func(item *layoutItem) {
+			if len(item.buttons) > 0 {
+				l.buttonItems = append(l.buttonItems, item)
+			}
+		})
+	}
+
+	if len(l.buttonItems) == 0 && l.buttonViews == nil {
+		return nil
+	}
+
+	want := make(map[string]bool)
+	for _, item := range l.buttonItems {
+		r, label := item.rect, item.buttonLabel()
+		if !item.displayed || r.X1-r.X0 < len([]rune(label))+4 {
+			continue
+		}
+		want[buttonsName(item)] = true
+		if err := l.layoutButton(g, item, label); err != nil {
+			return err
+		}
+	}
+
+	for name := range l.buttonViews {
+		if want[name] {
+			continue
+		}
+		g.DeleteKeybindings(name)
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	l.buttonViews = want
+	if len(want) == 0 {
+		l.buttonViews = nil
+	}
+
+	return nil
+}
+
+// buttonLabel returns the glyphs of the item's buttons, separated by spaces.
+func (l *layoutItem) buttonLabel() string {
+	glyphs := make([]string, len(l.buttons))
+	for i, b := range l.buttons {
+		glyphs[i] = ButtonGlyphs[b]
+	}
+	return strings.Join(glyphs, " ")
+}
+
+// layoutButton places the view holding the item's buttons over the right end
+// of the top border of its view, above it.
+func (l *layoutLevel) layoutButton(g *gocui.Gui, item *layoutItem, label string) error {
+	r, name := item.rect, buttonsName(item)
+	width := len([]rune(label))
+	v, err := g.SetView(name, r.X1-2-width, r.Y0-1, r.X1-1, r.Y0+1, 0)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Frame = false
+		if err := g.SetKeybinding(name, gocui.MouseLeft, gocui.ModNone,
+			func(g *gocui.Gui, v *gocui.View) error {
+				cx, _ := v.Cursor()
+				return l.pressButton(item, cx)
+			}); err != nil {
+			return err
+		}
+	}
+	if v.Buffer() != label {
+		v.Clear()
+		v.WriteString(label)
+	}
+
+	_, err = g.SetViewOnTop(name)
+	return err
+}
+
+// pressButton handles a click on the item's buttons, at column x of their
+// view.
+func (l *layoutLevel) pressButton(item *layoutItem, x int) error {
+	if x < 0 || x%2 == 1 || x/2 >= len(item.buttons) {
+		return nil
+	}
+
+	switch item.buttons[x/2] {
+	case ButtonCollapse:
+		return l.ToggleCollapse(item.name)
+	case ButtonZoom:
+		if l.zoomed == item {
+			l.Unzoom()
+			return nil
+		}
+		return l.Zoom(item.name)
+	case ButtonClose:
+		return l.RemoveItem(item.name)
+	}
+	return nil
+}
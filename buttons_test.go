@@ -0,0 +1,87 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestButtons(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewRatioItem(1, "a", WithButtons(ButtonCollapse, ButtonZoom, ButtonClose)),
+		NewRatioItem(1, "b", WithButtons(ButtonClose)),
+		NewRatioItem(1, "c"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a":         {0, 0, 79, 7},
+		"b":         {0, 8, 79, 15},
+		"c":         {0, 16, 79, 24},
+		"a~buttons": {72, -1, 78, 1},
+		"b~buttons": {76, 7, 78, 9},
+	})
+	v, err := g.View("a~buttons")
+	if err != nil {
+		t.Fatalf("Buttons not found: %v", err)
+	}
+	if got, want := v.Buffer(), "_ □ ×"; got != want {
+		t.Errorf("Unexpected buttons: got %q, want %q", got, want)
+	}
+
+	// Collapse a, then expand it again.
+	a := l.items[0]
+	if err := l.pressButton(a, 0); err != nil {
+		t.Fatalf("pressButton failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 79, 1},
+	})
+	if err := l.pressButton(a, 0); err != nil {
+		t.Fatalf("pressButton failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 79, 7},
+	})
+
+	// Spaces between buttons do nothing.
+	if err := l.pressButton(a, 1); err != nil {
+		t.Fatalf("pressButton failed: %v", err)
+	}
+	if err := l.pressButton(a, 2); err != nil {
+		t.Fatalf("pressButton failed: %v", err)
+	}
+	if got := l.Zoomed(); got != "a" {
+		t.Errorf("Expected a to be zoomed, got %q", got)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if viewExists(g, "b~buttons") {
+		t.Errorf("Buttons of b displayed while a is zoomed")
+	}
+	if err := l.pressButton(a, 2); err != nil {
+		t.Fatalf("pressButton failed: %v", err)
+	}
+	if got := l.Zoomed(); got != "" {
+		t.Errorf("Expected nothing to be zoomed, got %q", got)
+	}
+
+	if err := l.pressButton(l.items[1], 0); err != nil {
+		t.Fatalf("pressButton failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	for _, name := range []string{"b", "b~buttons"} {
+		if viewExists(g, name) {
+			t.Errorf("View %q of closed item not deleted", name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package layout
+
+import (
+	"sync/atomic"
+)
+
+// maxCached is the number of sizes for which each level keeps its geometry.
+const maxCached = 16
+
+// generation is incremented for every change to any layout, so that versions
+// are never reused.
+var generation uint64
+
+type cacheKey struct {
+	rect        Rect
+	overlap     int
+	forceHidden HideLayout
+}
+
+// changed marks the level as changed, invalidating any cached geometry in the
+// tree it belongs to.
+func (l *layoutLevel) changed() {
+	l.version = atomic.AddUint64(&generation, 1)
+}
+
+// treeVersion returns the latest version of any level in the tree, which
+// changes whenever any part of the tree does.
+func (l *layoutLevel) treeVersion() uint64 {
+	v := l.version
+	if l.takeover != nil {
+		if tv := l.takeover.treeVersion(); tv > v {
+			v = tv
+		}
+	}
+	for _, item := range l.items {
+		if item.inner != nil {
+			if iv := item.inner.treeVersion(); iv > v {
+				v = iv
+			}
+		}
+	}
+	return v
+}
+
+// place returns the placement of the level's items, as arrange does, reusing
+// the results of previous calls while the tree is at the same version, and
+// whether it did.
+func (l *layoutLevel) place(x0, y0, x1, y1, overlap int, forceHidden HideLayout, version uint64) ([]placement, bool, error) {
+	if l.cache == nil || l.cacheVersion != version || len(l.cache) >= maxCached {
+		l.cache = make(map[cacheKey][]placement)
+		l.cacheVersion = version
+	}
+
+	key := cacheKey{Rect{x0, y0, x1, y1}, overlap, forceHidden}
+	if places, ok := l.cache[key]; ok {
+		return places, true, nil
+	}
+
+	places, err := l.arrange(x0, y0, x1, y1, overlap, forceHidden)
+	if err != nil {
+		return nil, false, err
+	}
+	l.cache[key] = places
+	return places, false, nil
+}
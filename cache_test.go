@@ -0,0 +1,66 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestGeometryCache(t *testing.T) {
+	inner := NewLevel(LayoutVertical,
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "c"),
+	)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(inner)),
+	)
+
+	check := func(desc string, w, h int, want map[string]Rect) {
+		t.Helper()
+		got, err := l.Compute(w, h)
+		if err != nil {
+			t.Fatalf("%s: Compute failed: %v", desc, err)
+		}
+		for name, r := range want {
+			if got[name] != r {
+				t.Errorf("%s: Unexpected rect for %q: want %v, got %v", desc, name, r, got[name])
+			}
+		}
+	}
+
+	check("initial", 80, 20, map[string]Rect{
+		"a": {0, 0, 39, 19},
+		"c": {40, 10, 79, 19},
+	})
+	check("resized", 40, 10, map[string]Rect{
+		"a": {0, 0, 19, 9},
+		"c": {20, 5, 39, 9},
+	})
+	if len(l.cache) != 2 || len(inner.cache) != 2 {
+		t.Errorf("Unexpected cache sizes: got %d and %d, want 2 and 2", len(l.cache), len(inner.cache))
+	}
+	check("resized back", 80, 20, map[string]Rect{
+		"a": {0, 0, 39, 19},
+		"c": {40, 10, 79, 19},
+	})
+	if len(l.cache) != 2 || len(inner.cache) != 2 {
+		t.Errorf("Unexpected cache sizes: got %d and %d, want 2 and 2", len(l.cache), len(inner.cache))
+	}
+
+	// Changes made through any level are noticed.
+	if err := inner.ResizeItem("b", 0, 5); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	check("resized item", 80, 20, map[string]Rect{
+		"a": {0, 0, 39, 19},
+		"c": {40, 5, 79, 19},
+	})
+	if err := l.HideItem("b", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.HideItem("c", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	check("hidden level", 80, 20, map[string]Rect{
+		"a": {0, 0, 79, 19},
+	})
+}
@@ -0,0 +1,113 @@
+package layout
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// NotCarousel is an error returned when a carousel method is called on a level
+// that was not created with NewCarousel.
+var NotCarousel = fmt.Errorf("Level is not a carousel")
+
+type carouselState struct {
+	current int
+	stop    chan struct{}
+}
+
+// NewCarousel creates a level that displays only one of its items at a time,
+// using all of the level's space. Next and Prev cycle between the items, and
+// AutoRotate does so on a timer. The other items are treated as hidden.
+func NewCarousel(items ...*layoutItem) *layoutLevel {
+	l := NewLevel(LayoutVertical, items...)
+	l.carousel = &carouselState{}
+	return l
+}
+
+// Current returns the index of the item currently displayed by the carousel.
+func (l *layoutLevel) Current() (int, error) {
+	if l.carousel == nil {
+		return 0, NotCarousel
+	}
+
+	return l.carousel.current, nil
+}
+
+// Next displays the next item of the carousel, wrapping around after the last
+// one. Items hidden with HideItem are skipped.
+func (l *layoutLevel) Next() error {
+	return l.rotate(1)
+}
+
+// Prev displays the previous item of the carousel, wrapping around before the
+// first one. Items hidden with HideItem are skipped.
+func (l *layoutLevel) Prev() error {
+	return l.rotate(-1)
+}
+
+func (l *layoutLevel) rotate(step int) error {
+	if l.carousel == nil {
+		return NotCarousel
+	}
+
+	n := len(l.items)
+	if n == 0 {
+		return nil
+	}
+
+	next := l.carousel.current
+	for range l.items {
+		next = (next + step + n) % n
+		if !l.items[next].isHidden() {
+			l.carousel.current = next
+			l.changed()
+			break
+		}
+	}
+
+	return nil
+}
+
+// AutoRotate moves the carousel to the next item every interval, until
+// StopRotate is called. The layout is redrawn after each rotation.
+func (l *layoutLevel) AutoRotate(g *gocui.Gui, interval time.Duration) error {
+	if l.carousel == nil {
+		return NotCarousel
+	}
+
+	l.StopRotate()
+	stop := make(chan struct{})
+	l.carousel.stop = stop
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				g.Update(func(*gocui.Gui) error {
+					return l.Next()
+				})
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopRotate stops the rotation started by AutoRotate.
+func (l *layoutLevel) StopRotate() error {
+	if l.carousel == nil {
+		return NotCarousel
+	}
+
+	if l.carousel.stop != nil {
+		close(l.carousel.stop)
+		l.carousel.stop = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCarousel(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewCarousel(
+		NewRatioItem(1, "cpu"),
+		NewRatioItem(1, "disk", Hidden()),
+		NewRatioItem(1, "net"),
+	)
+
+	tests := []struct {
+		desc string
+		move func() error
+		want string
+		idx  int
+	}{
+		{"initial", func() error { return nil }, "cpu", 0},
+		{"next skips hidden", l.Next, "net", 2},
+		{"next wraps", l.Next, "cpu", 0},
+		{"prev wraps", l.Prev, "net", 2},
+	}
+
+	for _, tc := range tests {
+		if err := tc.move(); err != nil {
+			t.Fatalf("%s: move failed: %v", tc.desc, err)
+		}
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("%s: Layout failed: %v", tc.desc, err)
+		}
+		if idx, _ := l.Current(); idx != tc.idx {
+			t.Errorf("%s: unexpected index: got %d, want %d", tc.desc, idx, tc.idx)
+		}
+		v, err := g.ViewByPosition(40, 12)
+		if err != nil {
+			t.Fatalf("%s: can't find view: %v", tc.desc, err)
+		}
+		if v.Name() != tc.want {
+			t.Errorf("%s: unexpected view: got %q, want %q", tc.desc, v.Name(), tc.want)
+		}
+	}
+
+	if err := NewLevel(LayoutVertical).Next(); !errors.Is(err, NotCarousel) {
+		t.Errorf("Unexpected error for non-carousel: got %v, want %v", err, NotCarousel)
+	}
+}
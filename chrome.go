@@ -0,0 +1,28 @@
+package layout
+
+// chromeBody is the name of the item holding the body of a chrome layout.
+const chromeBody = "_chromeBody"
+
+// NewChrome surrounds body with an optional top and bottom bar, usually fixed
+// items such as menu or status lines. The bars stay in place whatever the
+// body displays, so it works well with Workspaces or routers. Either bar may
+// be nil. If body is a layout, its items can be found through the returned
+// level.
+func NewChrome(top, bottom *layoutItem, body RectManager) *layoutLevel {
+	var items []*layoutItem
+	if top != nil {
+		items = append(items, top)
+	}
+
+	if inner, ok := body.(*layoutLevel); ok {
+		items = append(items, NewRatioItem(1, chromeBody, WithInner(inner)))
+	} else {
+		items = append(items, NewRatioItem(1, chromeBody, WithManager(rectManager{body})))
+	}
+
+	if bottom != nil {
+		items = append(items, bottom)
+	}
+
+	return NewLevel(LayoutVertical, items...)
+}
@@ -0,0 +1,54 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestChrome(t *testing.T) {
+	g := newTestGui(t, false)
+	w := NewWorkspaces()
+	w.Add("one", NewLevel(LayoutHorizontal, NewRatioItem(1, "one")))
+	w.Add("two", NewLevel(LayoutHorizontal, NewRatioItem(1, "two")))
+	l := NewChrome(NewFixedItem(3, "menu"), NewFixedItem(3, "status"), w)
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"menu":   {0, 0, 79, 2},
+		"one":    {0, 3, 79, 21},
+		"status": {0, 22, 79, 24},
+	})
+
+	w.Switch("two")
+	if err := l.HideItem("menu", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"two":    {0, 0, 79, 21},
+		"status": {0, 22, 79, 24},
+	})
+}
+
+func TestChromeWithLevel(t *testing.T) {
+	g := newTestGui(t, false)
+	body := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "left"),
+		NewRatioItem(1, "right"),
+	)
+	l := NewChrome(nil, NewFixedItem(2, "status"), body)
+
+	if err := l.HideItem("right", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"left":   {0, 0, 79, 22},
+		"status": {0, 23, 79, 24},
+	})
+}
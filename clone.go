@@ -0,0 +1,74 @@
+package layout
+
+// Clone returns an independent copy of the layout, which can be rendered in
+// another gui, or changed, without affecting the original. Functions, such as
+// those passed to WithCreate and WithUpdate, and managers passed to WithManager
+// are shared by both copies. Keybindings, such as those set by EnableDrag, are
+// not copied.
+func (l *layoutLevel) Clone() *layoutLevel {
+	return l.CloneWithPrefix("")
+}
+
+// CloneWithPrefix returns a copy of the layout, as Clone does, with the prefix
+// added to the names of all items, so that both copies can be rendered in the
+// same gui.
+func (l *layoutLevel) CloneWithPrefix(prefix string) *layoutLevel {
+	c := &layoutLevel{
+		direction:    l.direction,
+		rounding:     l.rounding,
+		remainder:    l.remainder,
+		proportional: l.proportional,
+	}
+	if l.name != "" {
+		c.name = prefix + l.name
+	}
+	for _, item := range l.items {
+		c.items = append(c.items, item.clone(prefix))
+	}
+
+	if l.splitter != nil {
+		c.splitter = &splitState{
+			initial: l.splitter.initial,
+			percent: l.splitter.percent,
+			sash:    prefix + l.splitter.sash,
+		}
+	}
+	if l.carousel != nil {
+		c.carousel = &carouselState{current: l.carousel.current}
+	}
+	if l.takeover != nil {
+		c.takeover = l.takeover.CloneWithPrefix(prefix)
+	}
+
+	return c
+}
+
+func (l *layoutItem) clone(prefix string) *layoutItem {
+	c := &layoutItem{
+		ratio:       l.ratio,
+		fixed:       l.fixed,
+		name:        prefix + l.name,
+		hidden:      l.hidden,
+		itemOptions: l.itemOptions,
+	}
+	c.keys = append([]itemKey(nil), l.keys...)
+
+	if l.inner != nil {
+		c.inner = l.inner.CloneWithPrefix(prefix)
+	}
+	if l.router != nil {
+		c.router = &routerState{
+			routes:  map[string]*layoutLevel{},
+			current: l.router.current,
+		}
+		for route, level := range l.router.routes {
+			if level == l.inner {
+				c.router.routes[route] = c.inner
+			} else {
+				c.router.routes[route] = level.CloneWithPrefix(prefix)
+			}
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,47 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(10, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+			NewRatioItem(1, "d", Hidden()),
+		))),
+	)
+
+	c := l.Clone()
+	if got, want := describe(c), describe(l); got != want {
+		t.Errorf("Clone: want %q, got %q", want, got)
+	}
+
+	if err := c.RemoveItem("c"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := c.ResizeItem("a", 0, 20); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	if got, want := describe(l), "H(a V(c d))"; got != want {
+		t.Errorf("original changed: want %q, got %q", want, got)
+	}
+
+	g := newTestGui(t, false)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	p := l.CloneWithPrefix("copy.")
+	if err := p.HideItem("copy.a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := p.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a":      {0, 0, 9, 24},
+		"c":      {10, 0, 79, 24},
+		"copy.c": {0, 0, 79, 24},
+	})
+}
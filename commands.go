@@ -0,0 +1,290 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Direction identifies a side of an item, for commands that move or add items
+// relative to others.
+type Direction int
+
+const (
+	Left Direction = iota
+	Right
+	Up
+	Down
+)
+
+// axis returns the direction of the levels along which d moves.
+func (d Direction) axis() LayoutDirection {
+	if d == Left || d == Right {
+		return LayoutHorizontal
+	}
+	return LayoutVertical
+}
+
+// step returns -1 if d moves towards the start of a level, 1 otherwise.
+func (d Direction) step() int {
+	if d == Left || d == Up {
+		return -1
+	}
+	return 1
+}
+
+// newContainer creates an item holding the given level, named so it does not
+// collide with other items of the layout, or within the level.
+func (l *layoutLevel) newContainer(inner *layoutLevel) *layoutItem {
+	for {
+		l.containers++
+		name := fmt.Sprintf("_container%d", l.containers)
+		if _, err := l.findItem(name); err == nil {
+			continue
+		}
+		if _, err := inner.findItem(name); err == nil {
+			continue
+		}
+		return NewRatioItem(1, name, WithInner(inner))
+	}
+}
+
+// MoveItemDirection moves the named item one position in the given direction.
+// Within a level along that direction the item swaps places with its next
+// visible sibling. At the edge of the level, it moves out to the closest
+// enclosing level along that direction, next to the item that contained it.
+// If there is none, the whole layout is wrapped in a new level along the
+// direction, with the item placed on the requested side.
+func (l *layoutLevel) MoveItemDirection(name string, dir Direction) error {
+	levels, idx, err := l.findPath(name)
+	if err != nil {
+		return err
+	}
+
+	axis, step := dir.axis(), dir.step()
+	last := len(levels) - 1
+	if lv := levels[last]; lv.direction == axis {
+		for t := idx[last] + step; t >= 0 && t < len(lv.items); t += step {
+			if !lv.items[t].isHidden() {
+				l.record()
+				lv.items[idx[last]], lv.items[t] = lv.items[t], lv.items[idx[last]]
+				return nil
+			}
+		}
+	}
+
+	for k := last - 1; k >= 0; k-- {
+		if levels[k].direction != axis {
+			continue
+		}
+
+		l.record()
+		anchor := levels[k].items[idx[k]]
+		item, err := l.detach(name)
+		if err != nil {
+			return err
+		}
+
+		pos := idx[k]
+		for i, it := range levels[k].items {
+			if it == anchor {
+				pos = i
+				if step > 0 {
+					pos++
+				}
+			}
+		}
+		levels[k].insertAt(pos, item)
+
+		return nil
+	}
+
+	if l.direction == axis || (len(l.items) == 1 && last == 0) {
+		return nil
+	}
+
+	l.record()
+	item, err := l.detach(name)
+	if err != nil {
+		return err
+	}
+
+	rest := NewLevel(l.direction, l.items...)
+	rest.splitter, rest.carousel = l.splitter, l.carousel
+	l.splitter, l.carousel = nil, nil
+	l.direction = axis
+	if step < 0 {
+		l.items = []*layoutItem{item, l.newContainer(rest)}
+	} else {
+		l.items = []*layoutItem{l.newContainer(rest), item}
+	}
+
+	return nil
+}
+
+// SplitFocused adds item next to the item of the gui's current view, on the
+// given side. If the current item's level is not along that direction, the
+// current item is replaced by a new level holding both items, which takes
+// over the current item's size.
+func (l *layoutLevel) SplitFocused(g *gocui.Gui, dir Direction, item *layoutItem) error {
+	v := g.CurrentView()
+	if v == nil {
+		return &ItemError{Item: item.name, Err: NotFound}
+	}
+
+	if _, _, err := l.findPath(v.Name()); err != nil {
+		return err
+	}
+	if err := l.checkNew(item); err != nil {
+		return err
+	}
+
+	l.record()
+	return l.placeBeside(v.Name(), dir, item)
+}
+
+// placeBeside adds item next to the named item, on the given side, as
+// SplitFocused does.
+func (l *layoutLevel) placeBeside(name string, dir Direction, item *layoutItem) error {
+	levels, idx, err := l.findPath(name)
+	if err != nil {
+		return err
+	}
+	if err := l.checkNew(item); err != nil {
+		return err
+	}
+
+	lv, i := levels[len(levels)-1], idx[len(idx)-1]
+	axis, step := dir.axis(), dir.step()
+	if lv.direction == axis {
+		if step > 0 {
+			i++
+		}
+		lv.insertAt(i, item)
+		return nil
+	}
+
+	current := lv.items[i]
+	pair := NewLevel(axis, current, item)
+	if step < 0 {
+		pair.items[0], pair.items[1] = item, current
+	}
+
+	container := l.newContainer(pair)
+	container.ratio, container.fixed = current.ratio, current.fixed
+	current.ratio, current.fixed = 1, 0
+	lv.items[i] = container
+
+	return nil
+}
+
+// SwapDirection swaps the item of the gui's current view with the displayed
+// view nearest to it on the given side, as SwapItems does. The item keeps the
+// focus in its new place.
+func (l *layoutLevel) SwapDirection(g *gocui.Gui, dir Direction) error {
+	name, ok := l.focusedItem(g)
+	if !ok {
+		return nil
+	}
+	item, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	next := l.neighbor(item, dir)
+	if next == nil {
+		return nil
+	}
+	return l.SwapItems(name, next.name)
+}
+
+// neighbor returns the displayed view nearest to the item on the given side,
+// among those alongside it, preferring the one sharing the most of its edge.
+func (l *layoutLevel) neighbor(item *layoutItem, dir Direction) *layoutItem {
+	// Flip the rects so that dir is to the right, or down.
+	span := func(r Rect) (x0, x1, y0, y1 int) {
+		switch dir {
+		case Left:
+			return -r.X1, -r.X0, r.Y0, r.Y1
+		case Right:
+			return r.X0, r.X1, r.Y0, r.Y1
+		case Up:
+			return -r.Y1, -r.Y0, r.X0, r.X1
+		default:
+			return r.Y0, r.Y1, r.X0, r.X1
+		}
+	}
+
+	var found *layoutItem
+	bestGap, bestShared := 0, 0
+	_, end, from, to := span(item.rect)
+	l.visibleViews(func(other *layoutItem) {
+		x0, x1, y0, y1 := span(other.rect)
+		if other == item || x0 < end || x1 <= end {
+			return
+		}
+		if y0 < from {
+			y0 = from
+		}
+		if y1 > to {
+			y1 = to
+		}
+		shared := y1 - y0
+		if shared <= 0 {
+			return
+		}
+		gap := x0 - end
+		if found == nil || gap < bestGap || (gap == bestGap && shared > bestShared) {
+			found, bestGap, bestShared = other, gap, shared
+		}
+	})
+	return found
+}
+
+// CloseItem removes the named item from the layout, the same as RemoveItem.
+func (l *layoutLevel) CloseItem(name string) error {
+	return l.RemoveItem(name)
+}
+
+// CloseFocused removes the item of the gui's current view from the layout,
+// its view being deleted and its space shared by the rest on the next render,
+// when the next view in the focus order, or the previous one for the last, is
+// focused. If confirm is not nil, it is called with the item's name first, and
+// the item is only removed if it returns true.
+func (l *layoutLevel) CloseFocused(g *gocui.Gui, confirm func(name string) bool) error {
+	name, ok := l.focusedItem(g)
+	if !ok {
+		return nil
+	}
+	if confirm != nil && !confirm(name) {
+		return nil
+	}
+
+	var next string
+	items := l.focusOrder()
+	for idx, item := range items {
+		if item.name != name {
+			continue
+		}
+		if idx+1 < len(items) {
+			next = items[idx+1].name
+		} else if idx > 0 {
+			next = items[idx-1].name
+		}
+	}
+
+	if err := l.RemoveItem(name); err != nil {
+		return err
+	}
+	if next != "" {
+		return l.Focus(next)
+	}
+	return nil
+}
+
+// BindClose binds key, with mod, for all views, to CloseFocused.
+func (l *layoutLevel) BindClose(g *gocui.Gui, key interface{}, mod gocui.Modifier, confirm func(name string) bool) error {
+	return l.bindHelp(g, key, mod, "Close the focused item", func(g *gocui.Gui, _ *gocui.View) error {
+		return l.CloseFocused(g, confirm)
+	})
+}
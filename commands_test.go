@@ -0,0 +1,232 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func newCommandsLayout() *layoutLevel {
+	return NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+}
+
+func TestMoveItemDirection(t *testing.T) {
+	nested := func() *layoutLevel {
+		return NewLevel(LayoutHorizontal,
+			NewRatioItem(1, "x", WithInner(NewLevel(LayoutVertical,
+				NewRatioItem(1, "a"),
+			))),
+			NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+				NewRatioItem(1, "b"),
+				NewRatioItem(1, "c"),
+			))),
+		)
+	}
+
+	tests := []struct {
+		desc   string
+		layout *layoutLevel
+		name   string
+		dir    Direction
+		want   string
+	}{
+		{"swap within level", newCommandsLayout(), "b", Down, "H(a V(c b))"},
+		{"at the edge", newCommandsLayout(), "a", Left, "H(a V(b c))"},
+		{"out to the parent", newCommandsLayout(), "b", Left, "H(a b V(c))"},
+		{"out to the parent, after", newCommandsLayout(), "c", Right, "H(a V(b) c)"},
+		{"into a new root level", newCommandsLayout(), "a", Up, "V(a H(V(b c)))"},
+		{"replacing an emptied level", nested(), "a", Right, "H(a V(b c))"},
+	}
+
+	for _, tc := range tests {
+		l := tc.layout
+		if err := l.MoveItemDirection(tc.name, tc.dir); err != nil {
+			t.Errorf("%s: MoveItemDirection failed: %v", tc.desc, err)
+			continue
+		}
+		if got := describe(l); got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.desc, got, tc.want)
+		}
+	}
+
+	if err := newCommandsLayout().MoveItemDirection("missing", Up); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for missing item: got %v, want %v", err, NotFound)
+	}
+}
+
+func TestSplitFocused(t *testing.T) {
+	g := newTestGui(t, false)
+	l := newCommandsLayout()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	g.SetCurrentView("a")
+	if err := l.SplitFocused(g, Up, NewRatioItem(1, "d")); err != nil {
+		t.Fatalf("SplitFocused failed: %v", err)
+	}
+	g.SetCurrentView("b")
+	if err := l.SplitFocused(g, Down, NewRatioItem(1, "e")); err != nil {
+		t.Fatalf("SplitFocused failed: %v", err)
+	}
+	if got, want := describe(l), "H(V(d a) V(b e c))"; got != want {
+		t.Errorf("Unexpected layout: got %s, want %s", got, want)
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"d": {0, 0, 39, 11},
+		"a": {0, 12, 39, 24},
+	})
+
+	// Names already in use are refused.
+	var ie *ItemError
+	if err := l.SplitFocused(g, Left, NewRatioItem(1, "c")); !errors.Is(err, DuplicateName) || !errors.As(err, &ie) || ie.Item != "c" {
+		t.Errorf("Expected DuplicateName for c, got %v", err)
+	}
+	if err := l.SplitFocused(newTestGui(t, false), Left, NewRatioItem(1, "f")); !errors.Is(err, NotFound) || !errors.As(err, &ie) || ie.Item != "f" {
+		t.Errorf("Expected NotFound for f, got %v", err)
+	}
+}
+
+func TestNewContainer(t *testing.T) {
+	// Container names skip those of items, and are numbered per layout.
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "_container1"),
+		NewRatioItem(1, "a"),
+	)
+	if got := l.newContainer(NewLevel(LayoutVertical, NewRatioItem(1, "_container2"))).name; got != "_container3" {
+		t.Errorf("Unexpected container name: %s", got)
+	}
+	if got := newCommandsLayout().newContainer(NewLevel(LayoutVertical)).name; got != "_container1" {
+		t.Errorf("Unexpected container name in another layout: %s", got)
+	}
+}
+
+func TestCloseItem(t *testing.T) {
+	g := newTestGui(t, false)
+	l := newCommandsLayout()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	for _, name := range []string{"b", "c"} {
+		if err := l.CloseItem(name); err != nil {
+			t.Fatalf("CloseItem failed: %v", err)
+		}
+	}
+	if got, want := describe(l), "H(a)"; got != want {
+		t.Errorf("Unexpected layout: got %s, want %s", got, want)
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if len(g.Views()) != 1 {
+		t.Errorf("Views not deleted: %d views left", len(g.Views()))
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 79, 24},
+	})
+}
+
+func TestCloseFocused(t *testing.T) {
+	g := newTestGui(t, false)
+	l := newCommandsLayout()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.SetCurrentView("b"); err != nil {
+		t.Fatalf("SetCurrentView failed: %v", err)
+	}
+
+	var asked []string
+	confirm := func(name string) bool {
+		asked = append(asked, name)
+		return len(asked) > 1
+	}
+	if err := l.CloseFocused(g, confirm); err != nil {
+		t.Fatalf("CloseFocused failed: %v", err)
+	}
+	if got, want := describe(l), "H(a V(b c))"; got != want {
+		t.Errorf("Item closed without confirmation: got %s, want %s", got, want)
+	}
+
+	if err := l.CloseFocused(g, confirm); err != nil {
+		t.Fatalf("CloseFocused failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if got, want := describe(l), "H(a V(c))"; got != want {
+		t.Errorf("Unexpected layout: got %s, want %s", got, want)
+	}
+	if v := g.CurrentView(); v == nil || v.Name() != "c" {
+		t.Errorf("Expected c to be focused, got %v", v)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 39, 24},
+		"c": {40, 0, 79, 24},
+	})
+
+	// The last view gives the focus to the previous one.
+	if err := l.CloseFocused(g, nil); err != nil {
+		t.Fatalf("CloseFocused failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v := g.CurrentView(); v == nil || v.Name() != "a" {
+		t.Errorf("Expected a to be focused, got %v", v)
+	}
+	if viewExists(g, "c") {
+		t.Errorf("View of closed item not deleted")
+	}
+}
+
+func TestSwapDirection(t *testing.T) {
+	for _, overlap := range []bool{false, true} {
+		g := newTestGui(t, overlap)
+		l := NewLevel(LayoutHorizontal,
+			NewRatioItem(1, "a"),
+			NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+				NewRatioItem(2, "b"),
+				NewRatioItem(1, "c"),
+			))),
+		)
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+		if _, err := g.SetCurrentView("c"); err != nil {
+			t.Fatalf("SetCurrentView failed: %v", err)
+		}
+
+		for _, tc := range []struct {
+			dir  Direction
+			want string
+		}{
+			{Right, "H(a V(b c))"},
+			{Up, "H(a V(c b))"},
+			{Left, "H(c V(a b))"},
+			{Down, "H(c V(a b))"},
+			{Right, "H(a V(c b))"},
+		} {
+			if err := l.SwapDirection(g, tc.dir); err != nil {
+				t.Fatalf("SwapDirection failed: %v", err)
+			}
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			if got := describe(l); got != tc.want {
+				t.Errorf("Overlap %v, after swapping %v: got %s, want %s", overlap, tc.dir, got, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package layout
+
+import (
+	"errors"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// RectManager is implemented by managers that can lay themselves out within a
+// given rectangle, rather than over the whole screen.
+type RectManager interface {
+	LayoutRect(g *gocui.Gui, x0, y0, x1, y1 int) error
+}
+
+// RectManagerFunc is an adapter to allow the use of ordinary functions as
+// RectManagers.
+type RectManagerFunc func(g *gocui.Gui, x0, y0, x1, y1 int) error
+
+// LayoutRect calls f(g, x0, y0, x1, y1).
+func (f RectManagerFunc) LayoutRect(g *gocui.Gui, x0, y0, x1, y1 int) error {
+	return f(g, x0, y0, x1, y1)
+}
+
+// Layout calls f over the whole screen.
+func (f RectManagerFunc) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	return f(g, 0, 0, maxX-1, maxY-1)
+}
+
+// Compose combines several managers into one, calling them in order. This
+// allows a layout to be installed alongside other managers.
+func Compose(managers ...gocui.Manager) gocui.Manager {
+	return gocui.ManagerFunc(func(g *gocui.Gui) error {
+		for _, m := range managers {
+			if err := m.Layout(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// WithManager delegates the item's space to another manager, instead of
+// creating a view for it. If the manager implements RectManager it is given
+// the item's rectangle, otherwise its Layout method is called. The manager is
+// not called while the item is hidden.
+func WithManager(m gocui.Manager) layoutItemOption {
+	return func(l *layoutItem) {
+		l.manager = m
+	}
+}
+
+// rectManager adapts a RectManager for use where a gocui Manager is expected.
+type rectManager struct {
+	RectManager
+}
+
+// Layout renders the manager over the whole screen.
+func (m rectManager) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	return m.LayoutRect(g, 0, 0, maxX-1, maxY-1)
+}
+
+func layoutManager(g *gocui.Gui, m gocui.Manager, x0, y0, x1, y1 int) error {
+	if rm, ok := m.(RectManager); ok {
+		return rm.LayoutRect(g, x0, y0, x1, y1)
+	}
+	return m.Layout(g)
+}
+
+// LayoutRect renders the layout within the given rectangle, allowing it to be
+// used by WithManager or other RectManagers.
+func (l *layoutLevel) LayoutRect(g *gocui.Gui, x0, y0, x1, y1 int) error {
+	// The screen can have no size at all, such as while the terminal is
+	// starting up, or a single line or column, too small for even one view.
+	// Nothing can be displayed, so wait until it is bigger rather than fail.
+	if x1 <= x0 || y1 <= y0 {
+		return nil
+	}
+
+	if l.beforeLayout != nil {
+		l.beforeLayout(x1-x0+1, y1-y0+1)
+	}
+	var start time.Time
+	if l.onStats != nil {
+		start = time.Now()
+	}
+	err := l.render(g, x0, y0, x1, y1)
+	l.notifyStats(g, start, err)
+	if l.afterLayout != nil {
+		s := l.summary(g, x1-x0+1, y1-y0+1, err)
+		call(g, l.deferCallbacks, nil, func() error {
+			l.afterLayout(s)
+			return nil
+		})
+	}
+	return err
+}
+
+// render renders the layout within the given rectangle, which isn't empty.
+func (l *layoutLevel) render(g *gocui.Gui, x0, y0, x1, y1 int) error {
+	l.stats = Stats{}
+	l.checkZoom()
+	l.checkFloats()
+	if l.allHiddenPolicy == AllHiddenError && l.AllHidden() {
+		return NothingVisible
+	}
+
+	p := &pass{
+		hidden:      l.hiddenViews,
+		version:     l.treeVersion(),
+		root:        l,
+		dragBorders: l.dragBorders,
+		dragItems:   l.dragItems,
+		deferred:    l.deferCallbacks,
+		itemErrors:  l.itemErrors,
+		animate:     l.animate,
+		slide:       l.slide,
+		stats:       &l.stats,
+	}
+	if l.animate > 0 {
+		p.easing, p.now = l.easing, l.now()
+	}
+	if l.continueOnError || l.errorHandler != nil {
+		p.errs = &Errors{}
+	}
+	if err := l.layout(g, x0, y0, x1, y1, 0, LayoutVisible, p); err != nil {
+		if l.tooSmall && errors.Is(err, TooSmall) {
+			return l.layoutTooSmall(g, x0, y0, x1, y1)
+		}
+		return err
+	}
+	if l.tooSmall {
+		if err := g.DeleteView(tooSmallView); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	if err := l.layoutAllHidden(g, x0, y0, x1, y1); err != nil {
+		return err
+	}
+	if err := l.layoutFloats(g, x0, y0, x1, y1, p); err != nil {
+		return err
+	}
+	if err := l.applyFocus(g); err != nil {
+		return err
+	}
+	if err := l.layoutButtons(g, p.version); err != nil {
+		return err
+	}
+	if err := l.layoutModals(g, x0, y0, x1, y1); err != nil {
+		return err
+	}
+	l.styleFocus(g)
+	if err := l.bindClickFocus(g); err != nil {
+		return err
+	}
+	if err := l.bindItemKeys(g, p.version); err != nil {
+		return err
+	}
+	l.nextFrame(g, p)
+	l.track(p.version)
+	l.notifyVisibility(g, p.version)
+	l.notifyReady(g)
+	if l.snapshot == nil {
+		l.Snapshot()
+	}
+	if p.errs != nil && len(*p.errs) > 0 {
+		l.stats.Errors = len(*p.errs)
+		if l.errorHandler != nil {
+			l.handleErrors(*p.errs)
+			return nil
+		}
+		return *p.errs
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestWithManager(t *testing.T) {
+	g := newTestGui(t, false)
+	var got size
+	calls := 0
+	l := NewLevel(LayoutVertical,
+		NewRatioItem(1, "top"),
+		NewRatioItem(1, "custom", WithManager(RectManagerFunc(
+			func(g *gocui.Gui, x0, y0, x1, y1 int) error {
+				calls++
+				got = size{x0, y0, x1, y1}
+				return nil
+			}))),
+	)
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if want := (size{0, 12, 79, 24}); got != want {
+		t.Errorf("Unexpected rect for manager: got %s, want %s", got.String(), want.String())
+	}
+	if _, err := g.View("custom"); err != gocui.ErrUnknownView {
+		t.Errorf("View created for an item with a manager")
+	}
+
+	if err := l.HideItem("custom", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Manager called while hidden: %d calls", calls)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical, NewRatioItem(1, "main"))
+	other := gocui.ManagerFunc(func(g *gocui.Gui) error {
+		_, err := g.SetView("popup", 10, 10, 20, 15, 0)
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		return nil
+	})
+
+	if err := Compose(l, other).Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"main":  {0, 0, 79, 24},
+		"popup": {10, 10, 20, 15},
+	})
+}
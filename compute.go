@@ -0,0 +1,60 @@
+package layout
+
+// Rect is the area given to an item, with both corners included.
+type Rect struct {
+	X0, Y0, X1, Y1 int
+}
+
+// Compute returns the area each visible item would be given on a screen of the
+// given size, without rendering anything. The sizes match those of a gui
+// that does not support overlapping views. Items within a RectManager, such as
+// another layout passed to WithManager, are not included.
+func (l *layoutLevel) Compute(width, height int) (map[string]Rect, error) {
+	rects := make(map[string]Rect)
+	if err := l.compute(Rect{0, 0, width - 1, height - 1}, l.treeVersion(), rects); err != nil {
+		return nil, err
+	}
+	return rects, nil
+}
+
+// ItemRect returns the area the named item was given when the layout was last
+// rendered, and whether it was displayed then.
+func (l *layoutLevel) ItemRect(name string) (Rect, bool) {
+	item, err := l.findItem(name)
+	if err != nil || !item.displayed {
+		return Rect{}, false
+	}
+	return item.rect, true
+}
+
+func (l *layoutLevel) compute(r Rect, version uint64, rects map[string]Rect) error {
+	if l.takeover != nil {
+		return l.takeover.compute(r, version, rects)
+	}
+	if z := l.zoomed; z != nil {
+		rects[z.name] = r
+		if z.inner != nil {
+			return z.inner.compute(r, version, rects)
+		}
+		return nil
+	}
+
+	places, _, err := l.place(r.X0, r.Y0, r.X1, r.Y1, 1, LayoutVisible, version)
+	if err != nil {
+		return err
+	}
+
+	for idx, item := range l.items {
+		if places[idx].hidden {
+			continue
+		}
+		rects[item.name] = places[idx].rect
+		if item.inner != nil {
+			if err := item.inner.compute(places[idx].rect, version, rects); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
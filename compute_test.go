@@ -0,0 +1,103 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	l := NewLevel(LayoutVertical,
+		NewFixedItem(3, "header"),
+		NewRatioItem(1, "_body", WithInner(NewLevel(LayoutHorizontal,
+			NewFixedItem(20, "sidebar"),
+			NewRatioItem(1, "main"),
+			NewRatioItem(1, "hidden", Hidden()),
+		))),
+	)
+
+	got, err := l.Compute(80, 25)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	want := map[string]Rect{
+		"header":  {0, 0, 79, 2},
+		"_body":   {0, 3, 79, 24},
+		"sidebar": {0, 3, 19, 24},
+		"main":    {20, 3, 79, 24},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compute: want %v, got %v", want, got)
+	}
+
+	// The same sizes are used when rendering.
+	g := newTestGui(t, false)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"header":  {0, 0, 79, 2},
+		"sidebar": {0, 3, 19, 24},
+		"main":    {20, 3, 79, 24},
+	})
+
+	l.Takeover(NewLevel(LayoutVertical, NewRatioItem(1, "help")))
+	got, err = l.Compute(40, 10)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	want = map[string]Rect{
+		"help": {0, 0, 39, 9},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compute with a takeover: want %v, got %v", want, got)
+	}
+	l.EndTakeover()
+
+	if _, err := l.Compute(10, 2); err == nil {
+		t.Errorf("Compute succeeded on a screen that is too small")
+	}
+}
+
+func TestItemRect(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+
+	if _, ok := l.ItemRect("a"); ok {
+		t.Errorf("Expected no rect before rendering")
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want Rect
+		ok   bool
+	}{
+		{"a", Rect{0, 0, 39, 24}, true},
+		{"col", Rect{40, 0, 79, 24}, true},
+		{"c", Rect{40, 12, 79, 24}, true},
+		{"missing", Rect{}, false},
+	}
+	for _, tc := range tests {
+		if got, ok := l.ItemRect(tc.name); got != tc.want || ok != tc.ok {
+			t.Errorf("ItemRect(%q): want %v %v, got %v %v", tc.name, tc.want, tc.ok, got, ok)
+		}
+	}
+
+	if err := l.ToggleItem("col"); err != nil {
+		t.Fatalf("ToggleItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, ok := l.ItemRect("b"); ok {
+		t.Errorf("Expected no rect for an item in a hidden level")
+	}
+}
@@ -0,0 +1,69 @@
+package layout
+
+import (
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// debouncer holds off laying out a manager while the screen is being resized.
+type debouncer struct {
+	m     gocui.Manager
+	delay time.Duration
+
+	// The size the manager was last laid out at.
+	laid       bool
+	maxX, maxY int
+
+	// The size the screen is changing to, and since when.
+	nextX, nextY int
+	since        time.Time
+	timer        *time.Timer
+
+	// g.Size, replaced in tests.
+	size func(g *gocui.Gui) (int, int)
+}
+
+// Debounce returns a manager that renders m, except while the screen is being
+// resized, such as when dragging the terminal window. Once the size has stayed
+// the same for delay, m is rendered again, once, rather than for every step
+// along the way, so that views don't flicker and update functions aren't
+// called needlessly.
+func Debounce(m gocui.Manager, delay time.Duration) gocui.Manager {
+	return &debouncer{
+		m:     m,
+		delay: delay,
+		size:  (*gocui.Gui).Size,
+	}
+}
+
+// Layout renders the manager, unless the screen size changed within the delay.
+func (d *debouncer) Layout(g *gocui.Gui) error {
+	maxX, maxY := d.size(g)
+	if d.laid && (maxX != d.maxX || maxY != d.maxY) {
+		if maxX != d.nextX || maxY != d.nextY {
+			d.nextX, d.nextY = maxX, maxY
+			d.since = time.Now()
+		}
+		if wait := d.delay - time.Since(d.since); wait > 0 {
+			// Make sure the gui is redrawn once the size settles, even if
+			// there are no other events by then.
+			if d.timer != nil {
+				d.timer.Stop()
+			}
+			d.timer = time.AfterFunc(wait, func() {
+				g.Update(func(*gocui.Gui) error { return nil })
+			})
+			return nil
+		}
+	}
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.laid = true
+	d.maxX, d.maxY = maxX, maxY
+	d.nextX, d.nextY = maxX, maxY
+	return d.m.Layout(g)
+}
@@ -0,0 +1,49 @@
+package layout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestDebounce(t *testing.T) {
+	g := newTestGui(t, false)
+	var calls int
+	var maxX, maxY int
+	d := Debounce(gocui.ManagerFunc(func(*gocui.Gui) error {
+		calls++
+		return nil
+	}), 50*time.Millisecond).(*debouncer)
+	d.size = func(*gocui.Gui) (int, int) { return maxX, maxY }
+
+	layout := func(x, y int) {
+		t.Helper()
+		maxX, maxY = x, y
+		if err := d.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+
+	// The first render, and those at the same size, aren't delayed.
+	layout(80, 25)
+	layout(80, 25)
+	if calls != 2 {
+		t.Errorf("Expected 2 renders, got %d", calls)
+	}
+
+	// Resizing is held off until the size settles.
+	for i := 1; i <= 5; i++ {
+		layout(80+i, 25)
+		time.Sleep(20 * time.Millisecond)
+	}
+	if calls != 2 {
+		t.Errorf("Expected no renders while resizing, got %d", calls-2)
+	}
+	time.Sleep(40 * time.Millisecond)
+	layout(85, 25)
+	layout(85, 25)
+	if calls != 4 {
+		t.Errorf("Expected 2 renders after resizing, got %d", calls-2)
+	}
+}
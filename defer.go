@@ -0,0 +1,47 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// DeferCallbacks sets whether the functions given to the layout are called
+// through the gui's Update, once the layout was rendered, rather than while it
+// is being rendered, so that they can block or change other views safely.
+// This covers the create, update, resize, focus, blur and destroy functions of
+// items, and the functions set with OnVisibilityChange and AfterLayout, but
+// not BeforeLayout, which can change the render. Views are then displayed
+// before being filled in by their create function. Errors from deferred
+// functions are returned by the gui's MainLoop, attributed to their item.
+func (l *layoutLevel) DeferCallbacks(enabled bool) {
+	l.deferCallbacks = enabled
+}
+
+// call calls f, or schedules it with the gui's Update if deferred. The errors
+// of deferred functions are attributed to the item, if any, as they are no
+// longer returned to the code handling the item's errors.
+func call(g *gocui.Gui, deferred bool, item *layoutItem, f func() error) error {
+	if !deferred {
+		return f()
+	}
+	g.Update(func(*gocui.Gui) error {
+		err := f()
+		if err != nil && item != nil {
+			return wrapItemError(item, err)
+		}
+		return err
+	})
+	return nil
+}
+
+// deferView returns a function scheduling f, called with a view of the item,
+// with the gui's Update, or nil if f is.
+func (l *layoutItem) deferView(g *gocui.Gui, f func(*gocui.View) error) func(*gocui.View) error {
+	if f == nil {
+		return nil
+	}
+	return func(v *gocui.View) error {
+		return call(g, true, l, func() error {
+			return f(v)
+		})
+	}
+}
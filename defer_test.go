@@ -0,0 +1,107 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestDeferCallbacks(t *testing.T) {
+	g := newTestGui(t, false)
+	errCreate := errors.New("create failed")
+	rendering := false
+	created := make(chan bool, 1)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithCreate(func(v *gocui.View) error {
+			created <- rendering
+			return errCreate
+		})),
+		NewRatioItem(1, "b"),
+	)
+	l.DeferCallbacks(true)
+	l.BeforeLayout(func(w, h int) {
+		rendering = true
+	})
+	g.SetManager(Compose(l, gocui.ManagerFunc(func(*gocui.Gui) error {
+		rendering = false
+		return nil
+	})))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- g.MainLoop()
+	}()
+
+	select {
+	case during := <-created:
+		if during {
+			t.Errorf("Expected the create function to be called after the layout")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Create function not called")
+	}
+
+	// Errors from deferred functions end the main loop.
+	select {
+	case err := <-done:
+		var ie *ItemError
+		if !errors.As(err, &ie) || ie.Item != "a" || !errors.Is(err, errCreate) {
+			t.Errorf("Expected the create error for a, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Main loop didn't return the create error")
+	}
+}
+
+func TestDeferDestroy(t *testing.T) {
+	g := newTestGui(t, false)
+	destroyed := make(chan error, 1)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithDestroy(func(v *gocui.View) error {
+			_, err := g.View("a")
+			destroyed <- err
+			return nil
+		})),
+		NewRatioItem(1, "b"),
+	)
+	l.DeferCallbacks(true)
+	g.SetManager(l)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.RemoveItem("a"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if !viewExists(g, "a") {
+		t.Errorf("View a was deleted before its destroy function was called")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- g.MainLoop()
+	}()
+
+	select {
+	case err := <-destroyed:
+		if err != nil {
+			t.Errorf("Expected the view to exist when destroyed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Destroy function not called")
+	}
+
+	g.Update(func(g *gocui.Gui) error {
+		if viewExists(g, "a") {
+			t.Errorf("View a was not deleted")
+		}
+		return gocui.ErrQuit
+	})
+	if err := <-done; err != gocui.ErrQuit {
+		t.Errorf("Unexpected error from the main loop: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// WithDestroy calls f with the item's view just before the layout deletes it,
+// such as once the item was removed, its workspace or route was switched
+// away from, or it was hidden with the HiddenDeleted policy, so that anything
+// tied to the view can be released. Views kept along with their contents, such
+// as when an item is renamed, are not destroyed.
+func WithDestroy(f func(*gocui.View) error) layoutItemOption {
+	return func(l *layoutItem) {
+		l.fDestroy = f
+	}
+}
+
+// viewOwner is an item or level whose views can be dropped.
+type viewOwner interface {
+	appendViewNames(names []string) []string
+	viewItems(f func(*layoutItem))
+}
+
+// viewItems calls f for the item, if it is displayed as a view, or for every
+// such item within it.
+func (l *layoutItem) viewItems(f func(*layoutItem)) {
+	if l.inner != nil {
+		l.inner.viewItems(f)
+	} else if l.manager == nil {
+		f(l)
+	}
+}
+
+// dropItems marks the views of o to be deleted on the next layout, the same
+// as dropViews, calling the destroy functions of its items for those that are.
+func (l *layoutLevel) dropItems(o viewOwner) {
+	l.dropViews(o.appendViewNames(nil))
+	o.viewItems(func(item *layoutItem) {
+		if item.fDestroy == nil {
+			return
+		}
+		if l.destroys == nil {
+			l.destroys = make(map[string]func(*gocui.View) error)
+		}
+		l.destroys[item.name] = item.fDestroy
+	})
+}
+
+// deleteView deletes the named view, calling f with it first, if set and the
+// view exists. If deferred, both are scheduled together with the gui's Update,
+// so that f still finds the view.
+func deleteView(g *gocui.Gui, name string, f func(*gocui.View) error, deferred bool) error {
+	v, err := g.View(name)
+	if err != nil {
+		return nil
+	}
+	if f == nil {
+		return g.DeleteView(name)
+	}
+	return call(g, deferred, nil, func() error {
+		if err := f(v); err != nil {
+			return err
+		}
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,72 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestWithDestroy(t *testing.T) {
+	g := newTestGui(t, false)
+	var destroyed []string
+	destroy := WithDestroy(func(v *gocui.View) error {
+		destroyed = append(destroyed, v.Name())
+		return nil
+	})
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", destroy),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b", destroy),
+			NewRatioItem(1, "c"),
+		))),
+		NewRatioItem(1, "d", destroy),
+	)
+	render := func() {
+		t.Helper()
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	render()
+
+	// Removed items are destroyed on the next render.
+	if err := l.RemoveItem("col"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if len(destroyed) != 0 {
+		t.Errorf("Destroyed before rendering: %v", destroyed)
+	}
+	render()
+	if want := []string{"b"}; !reflect.DeepEqual(destroyed, want) {
+		t.Errorf("Unexpected destroyed views: got %v, want %v", destroyed, want)
+	}
+
+	// Views kept in the tree aren't, even when dropped.
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	render()
+	if want := []string{"b"}; !reflect.DeepEqual(destroyed, want) {
+		t.Errorf("Unexpected destroyed views: got %v, want %v", destroyed, want)
+	}
+
+	// Nor are renamed ones, which keep their contents.
+	if err := l.RenameItem("a", "e"); err != nil {
+		t.Fatalf("RenameItem failed: %v", err)
+	}
+	render()
+	if want := []string{"b"}; !reflect.DeepEqual(destroyed, want) {
+		t.Errorf("Unexpected destroyed views: got %v, want %v", destroyed, want)
+	}
+
+	// Hidden views are destroyed when deleted.
+	l.SetHiddenViews(HiddenDeleted)
+	if err := l.HideItem("d", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	render()
+	if want := []string{"b", "d"}; !reflect.DeepEqual(destroyed, want) {
+		t.Errorf("Unexpected destroyed views: got %v, want %v", destroyed, want)
+	}
+}
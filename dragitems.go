@@ -0,0 +1,295 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// dropView is the name of the view marking where a dragged item would be
+// dropped.
+const dropView = "_layoutDrop"
+
+// EnableDragItems allows items to be moved with the mouse: clicking on the top
+// border of an item's view, where its title is, picks the item up, and a line
+// marks where it would go as the mouse moves. The next click drops it there.
+// Dropped close to an edge of another item, the item is placed on that side
+// of it, splitting its space if needed, as SplitFocused does. Otherwise it
+// moves among its siblings, or stays in place if outside its level. The gui
+// must have Mouse enabled.
+func (l *layoutLevel) EnableDragItems(enabled bool) {
+	l.dragItems = enabled
+	l.changed()
+}
+
+// gripName returns the name of the view over the top border of the item.
+func gripName(item *layoutItem) string {
+	return item.name + "~grip"
+}
+
+// layoutGrips places invisible views over the top borders of the views of the
+// level's displayed items, to receive the clicks that pick them up, and
+// deletes those no longer needed.
+func (l *layoutLevel) layoutGrips(g *gocui.Gui, p *pass, forceHidden HideLayout) error {
+	enabled := p.dragItems && p.zoomed == nil && !bool(forceHidden) && l.carousel == nil
+	if !enabled && l.grips == nil {
+		return nil
+	}
+
+	want := make(map[string]*layoutItem)
+	if enabled {
+		for idx, item := range l.items {
+			r := item.rect
+			if l.hidden(idx) || item.inner != nil || item.manager != nil || r.X1-r.X0 < 2 {
+				continue
+			}
+			want[gripName(item)] = item
+			if err := l.layoutGrip(g, p.root, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name := range l.grips {
+		if _, ok := want[name]; ok {
+			continue
+		}
+		g.DeleteKeybindings(name)
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	l.grips = want
+	if len(want) == 0 {
+		l.grips = nil
+	}
+
+	return nil
+}
+
+// layoutGrip places the view over the top border of the item. Moves are
+// recorded by root.
+func (l *layoutLevel) layoutGrip(g *gocui.Gui, root *layoutLevel, item *layoutItem) error {
+	r := item.rect
+	name := gripName(item)
+	v, err := g.SetView(name, r.X0, r.Y0-1, r.X1, r.Y0+1, 0)
+	if err == gocui.ErrUnknownView {
+		v.Visible = false
+		v.Frame = false
+		return g.SetKeybinding(name, gocui.MouseLeft, gocui.ModNone,
+			func(g *gocui.Gui, v *gocui.View) error {
+				return l.startItemDrag(g, root, item)
+			})
+	}
+	return err
+}
+
+// startItemDrag picks up the item, marking where it would be dropped until
+// the next click.
+func (l *layoutLevel) startItemDrag(g *gocui.Gui, root *layoutLevel, item *layoutItem) error {
+	return startCapture(g,
+		func(x, y int) error {
+			return l.markDrop(g, root, item, x, y)
+		},
+		func(x, y int) error {
+			if err := g.DeleteView(dropView); err != nil && err != gocui.ErrUnknownView {
+				return err
+			}
+			return l.drop(root, item, x, y)
+		})
+}
+
+// drop moves the item dropped at the given position: next to another item,
+// when dropped close to one of its edges, or among its siblings.
+func (l *layoutLevel) drop(root *layoutLevel, item *layoutItem, x, y int) error {
+	if target, dir, ok := l.splitTarget(root, item, x, y); ok {
+		root.record()
+		moved, err := root.detach(item.name)
+		if err != nil {
+			return err
+		}
+		return root.placeBeside(target.name, dir, moved)
+	}
+
+	pos, ok := l.dropAt(x, y)
+	if !ok {
+		return nil
+	}
+	return l.moveTo(root, item, pos)
+}
+
+// splitTarget returns the item under the given position, and its edge nearest
+// to it, when close enough to that edge for the dropped item to be placed on
+// that side. Edges of the item's siblings along the level are left to
+// reordering.
+func (l *layoutLevel) splitTarget(root *layoutLevel, item *layoutItem, x, y int) (*layoutItem, Direction, bool) {
+	target := root.itemAt(x, y)
+	if target == nil || target == item {
+		return nil, 0, false
+	}
+
+	dir, ok := edgeAt(target.rect, x, y)
+	if !ok {
+		return nil, 0, false
+	}
+	if dir.axis() == l.direction {
+		for _, it := range l.items {
+			if it == target {
+				return nil, 0, false
+			}
+		}
+	}
+
+	return target, dir, true
+}
+
+// edgeAt returns the edge of r nearest to the given position, if it is within
+// a quarter of r's width or height from it.
+func edgeAt(r Rect, x, y int) (Direction, bool) {
+	w, h := r.X1-r.X0+1, r.Y1-r.Y0+1
+	dir, best := Left, 0.25
+	for _, e := range []struct {
+		dir  Direction
+		dist float64
+	}{
+		{Left, float64(x-r.X0) / float64(w)},
+		{Right, float64(r.X1-x) / float64(w)},
+		{Up, float64(y-r.Y0) / float64(h)},
+		{Down, float64(r.Y1-y) / float64(h)},
+	} {
+		if e.dist < best {
+			dir, best = e.dir, e.dist
+		}
+	}
+
+	return dir, best < 0.25
+}
+
+// dropAt returns the index in the level before which an item dropped at the
+// given position goes, and whether the position is within the level.
+func (l *layoutLevel) dropAt(x, y int) (int, bool) {
+	r := l.rect
+	if x < r.X0 || x > r.X1 || y < r.Y0 || y > r.Y1 {
+		return 0, false
+	}
+
+	pos := x
+	if l.direction == LayoutVertical {
+		pos = y
+	}
+	after := -1
+	for idx, item := range l.items {
+		if l.hidden(idx) {
+			continue
+		}
+		start, end := item.rect.X0, item.rect.X1
+		if l.direction == LayoutVertical {
+			start, end = item.rect.Y0, item.rect.Y1
+		}
+		if pos <= (start+end)/2 {
+			return idx, true
+		}
+		after = idx
+	}
+
+	return after + 1, true
+}
+
+// markDrop shows a line where the item dropped at the given position would
+// go, or removes it if it would stay in place.
+func (l *layoutLevel) markDrop(g *gocui.Gui, root *layoutLevel, item *layoutItem, x, y int) error {
+	if target, dir, ok := l.splitTarget(root, item, x, y); ok {
+		r := target.rect
+		switch dir {
+		case Left:
+			return showDrop(g, LayoutHorizontal, r.X0, r)
+		case Right:
+			return showDrop(g, LayoutHorizontal, r.X1, r)
+		case Up:
+			return showDrop(g, LayoutVertical, r.Y0, r)
+		default:
+			return showDrop(g, LayoutVertical, r.Y1, r)
+		}
+	}
+
+	pos, ok := l.dropAt(x, y)
+	if !ok {
+		if err := g.DeleteView(dropView); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		return nil
+	}
+
+	// The line goes over the start of the item at pos, or the end of the
+	// last displayed item.
+	edge := -1
+	for idx := pos; idx < len(l.items) && edge < 0; idx++ {
+		if !l.hidden(idx) {
+			edge = l.items[idx].rect.X0
+			if l.direction == LayoutVertical {
+				edge = l.items[idx].rect.Y0
+			}
+		}
+	}
+	for idx := pos - 1; idx >= 0 && edge < 0; idx-- {
+		if !l.hidden(idx) {
+			edge = l.items[idx].rect.X1
+			if l.direction == LayoutVertical {
+				edge = l.items[idx].rect.Y1
+			}
+		}
+	}
+	if edge < 0 {
+		return nil
+	}
+
+	return showDrop(g, l.direction, edge, l.rect)
+}
+
+// showDrop draws the drop marker across r, over the column at edge for
+// horizontal levels, or over the row for vertical ones.
+func showDrop(g *gocui.Gui, direction LayoutDirection, edge int, r Rect) error {
+	x0, y0, x1, y1 := edge-1, r.Y0, edge+1, r.Y1
+	line := strings.Repeat("┃\n", y1-y0-1)
+	if direction == LayoutVertical {
+		x0, y0, x1, y1 = r.X0, edge-1, r.X1, edge+1
+		line = strings.Repeat("━", x1-x0-1)
+	}
+
+	v, err := g.SetView(dropView, x0, y0, x1, y1, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Frame = false
+	v.FgColor = gocui.ColorYellow
+	v.Clear()
+	v.WriteString(line)
+	if _, err := g.SetViewOnTop(dropView); err != nil {
+		return err
+	}
+	// The capture must stay on top to keep receiving the mouse.
+	_, err = g.SetViewOnTop(captureView)
+	return err
+}
+
+// moveTo moves the item of the level to before the item at pos.
+func (l *layoutLevel) moveTo(root *layoutLevel, item *layoutItem, pos int) error {
+	from := -1
+	for idx, it := range l.items {
+		if it == item {
+			from = idx
+		}
+	}
+	if from < 0 || pos == from || pos == from+1 {
+		return nil
+	}
+
+	root.record()
+	l.removeAt(from)
+	if pos > from {
+		pos--
+	}
+	l.insertAt(pos, item)
+
+	return nil
+}
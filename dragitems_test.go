@@ -0,0 +1,181 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDragItems(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(2, "c"),
+	)
+	l.EnableDragItems(true)
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a":      {0, 0, 19, 24},
+		"b":      {20, 0, 39, 24},
+		"c":      {40, 0, 79, 24},
+		"a~grip": {0, -1, 19, 1},
+		"b~grip": {20, -1, 39, 1},
+		"c~grip": {40, -1, 79, 1},
+	})
+
+	for _, tc := range []struct {
+		x, y int
+		pos  int
+		ok   bool
+	}{
+		{5, 5, 0, true},
+		{15, 5, 1, true},
+		{30, 5, 2, true},
+		{75, 5, 3, true},
+		{75, 30, 0, false},
+	} {
+		pos, ok := l.dropAt(tc.x, tc.y)
+		if pos != tc.pos || ok != tc.ok {
+			t.Errorf("dropAt(%d, %d): got %d, %v, want %d, %v", tc.x, tc.y, pos, ok, tc.pos, tc.ok)
+		}
+	}
+
+	if err := l.startItemDrag(g, l, l.items[0]); err != nil {
+		t.Fatalf("startItemDrag failed: %v", err)
+	}
+	if err := l.markDrop(g, l, l.items[0], 75, 5); err != nil {
+		t.Fatalf("markDrop failed: %v", err)
+	}
+	v, err := g.View(dropView)
+	if err != nil {
+		t.Fatalf("Drop marker not found: %v", err)
+	}
+	if x0, y0, x1, y1 := v.Dimensions(); x0 != 78 || y0 != 0 || x1 != 80 || y1 != 24 {
+		t.Errorf("Unexpected drop marker: got {%d %d %d %d}, want {78 0 80 24}", x0, y0, x1, y1)
+	}
+	if got := v.Buffer(); !strings.HasPrefix(got, "┃\n┃") {
+		t.Errorf("Unexpected drop marker contents: %q", got)
+	}
+	if err := l.markDrop(g, l, l.items[0], 75, 30); err != nil {
+		t.Fatalf("markDrop failed: %v", err)
+	}
+	if viewExists(g, dropView) {
+		t.Errorf("Drop marker not removed outside the level")
+	}
+
+	if err := l.moveTo(l, l.items[0], 3); err != nil {
+		t.Fatalf("moveTo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {0, 0, 19, 24},
+		"c": {20, 0, 59, 24},
+		"a": {60, 0, 79, 24},
+	})
+
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.HideItem("b", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if viewExists(g, "b~grip") {
+		t.Errorf("Grip of hidden item still exists")
+	}
+
+	l.EnableDragItems(false)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	for _, name := range []string{"a~grip", "c~grip"} {
+		if viewExists(g, name) {
+			t.Errorf("Grip %q still exists after disabling", name)
+		}
+	}
+}
+
+func TestDragItemsSplit(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(2, "right", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+			NewRatioItem(1, "d"),
+		))),
+	)
+	l.EnableDragItems(true)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		x, y   int
+		target string
+		dir    Direction
+	}{
+		{60, 1, "c", Up},
+		{78, 5, "c", Right},
+		{60, 23, "d", Down},
+		{22, 10, "", 0},
+		{25, 1, "b", Up},
+		{60, 7, "", 0},
+	} {
+		target, dir, ok := l.splitTarget(l, l.items[0], tc.x, tc.y)
+		name := ""
+		if ok {
+			name = target.name
+		}
+		if name != tc.target || dir != tc.dir {
+			t.Errorf("splitTarget(%d, %d): got %q, %v, want %q, %v", tc.x, tc.y, name, dir, tc.target, tc.dir)
+		}
+	}
+
+	if err := l.startItemDrag(g, l, l.items[0]); err != nil {
+		t.Fatalf("startItemDrag failed: %v", err)
+	}
+	if err := l.markDrop(g, l, l.items[0], 78, 5); err != nil {
+		t.Fatalf("markDrop failed: %v", err)
+	}
+	v, err := g.View(dropView)
+	if err != nil {
+		t.Fatalf("Drop marker not found: %v", err)
+	}
+	if x0, y0, x1, y1 := v.Dimensions(); x0 != 78 || y0 != 0 || x1 != 80 || y1 != 11 {
+		t.Errorf("Unexpected drop marker: got {%d %d %d %d}, want {78 0 80 11}", x0, y0, x1, y1)
+	}
+
+	if err := l.drop(l, l.items[0], 78, 5); err != nil {
+		t.Fatalf("drop failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {0, 0, 25, 24},
+		"c": {26, 0, 52, 11},
+		"a": {53, 0, 79, 11},
+		"d": {26, 12, 79, 24},
+	})
+
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 19, 24},
+		"b": {20, 0, 39, 24},
+		"c": {40, 0, 79, 11},
+		"d": {40, 12, 79, 24},
+	})
+}
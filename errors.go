@@ -0,0 +1,169 @@
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// ItemError is the error returned when something goes wrong with a specific
+// item. It wraps the underlying error, such as NotFound or InvalidValues, so
+// that errors.Is can be used to check for those.
+type ItemError struct {
+	// The name of the item.
+	Item string
+	// The names of the items holding the levels the item is in, starting
+	// from the top of the layout. Empty for items of the layout itself, or
+	// items that aren't in the layout.
+	Path []string
+	// The underlying error.
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("item '%s': %v", e.Item, e.Err)
+	}
+	return fmt.Sprintf("item '%s' in level %s: %v", e.Item, strings.Join(e.Path, "/"), e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// itemError wraps err with the named item and its location in the layout.
+func (l *layoutLevel) itemError(name string, err error) error {
+	path, _ := l.PathTo(name)
+	if len(path) > 0 {
+		path = path[:len(path)-1]
+	}
+	return &ItemError{Item: name, Path: path, Err: err}
+}
+
+// wrapItemError attributes an error from rendering the item to it. Errors
+// already attributed to an item within it have the item added to their path.
+func wrapItemError(item *layoutItem, err error) error {
+	var ie *ItemError
+	if errors.As(err, &ie) {
+		ie.Path = append([]string{item.name}, ie.Path...)
+		return err
+	}
+	return &ItemError{Item: item.name, Err: err}
+}
+
+// Errors holds the errors from rendering several items, when the layout is
+// set to continue after errors with ContinueOnError. errors.Is and errors.As
+// match any of them.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports if any of the errors matches target.
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors that matches target.
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// collect adds an error from rendering the item, and adds the item to the path
+// of those from items within it, added since mark.
+func (e *Errors) collect(item *layoutItem, mark int, err error) {
+	for i := mark; i < len(*e); i++ {
+		(*e)[i] = wrapItemError(item, (*e)[i])
+	}
+	if err != nil {
+		*e = append(*e, wrapItemError(item, err))
+	}
+}
+
+// ContinueOnError sets whether the layout carries on rendering the rest of the
+// items after one fails, such as when its create or update function returns
+// an error. The errors are returned together, as Errors, once the layout is
+// rendered.
+func (l *layoutLevel) ContinueOnError(enabled bool) {
+	l.continueOnError = enabled
+}
+
+// SetErrorHandler sets a function to call with the errors from rendering the
+// layout's items, such as those returned by their create or update functions,
+// or by gocui's SetView, along with the name of the item, so that the
+// application can log or display them. Once set, the rest of the layout is
+// rendered after an item fails, as with ContinueOnError, and the errors passed
+// to f are not returned. Errors not coming from a specific item, such as
+// TooSmall when the whole layout doesn't fit, are still returned. A nil f
+// removes the handler.
+func (l *layoutLevel) SetErrorHandler(f func(item string, err error)) {
+	l.errorHandler = f
+}
+
+// handleErrors passes the errors from rendering items to the error handler.
+func (l *layoutLevel) handleErrors(errs Errors) {
+	for _, err := range errs {
+		var ie *ItemError
+		if errors.As(err, &ie) {
+			l.errorHandler(ie.Item, ie.Err)
+		}
+	}
+}
+
+// ItemErrors selects what is done when the create or update function of an
+// item fails.
+type ItemErrors int
+
+const (
+	// ItemErrorsAbort stops rendering the layout, returning the error, unless
+	// ContinueOnError or SetErrorHandler were used.
+	ItemErrorsAbort ItemErrors = iota
+	// ItemErrorsIsolate marks only the item as failed, see ItemState, and
+	// renders the rest of the layout as if nothing happened. The error is
+	// not returned.
+	ItemErrorsIsolate
+	// ItemErrorsShow does the same as ItemErrorsIsolate, and also replaces
+	// the contents of the item's view with the error.
+	ItemErrorsShow
+)
+
+// SetItemErrors sets what is done when the create or update function of an
+// item returns an error. By default, the error aborts the render
+// (ItemErrorsAbort). Otherwise, the item is marked as failed until its
+// function next succeeds.
+func (l *layoutLevel) SetItemErrors(mode ItemErrors) {
+	l.itemErrors = mode
+}
+
+// isolate returns a function calling f and keeping its error in the item,
+// rather than returning it, or nil if f is.
+func (l *layoutItem) isolate(f func(*gocui.View) error, mode ItemErrors) func(*gocui.View) error {
+	if f == nil {
+		return nil
+	}
+	return func(v *gocui.View) error {
+		l.err = f(v)
+		if l.err != nil && mode == ItemErrorsShow {
+			v.Clear()
+			fmt.Fprintf(v, "Error: %v", l.err)
+		}
+		return nil
+	}
+}
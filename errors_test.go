@@ -0,0 +1,326 @@
+package layout
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestItemError(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "sidebar", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "roster"),
+		))),
+	)
+
+	err := l.ResizeItem("roster", 1, 1)
+	if !errors.Is(err, InvalidValues) {
+		t.Errorf("Unexpected error: got %v, want %v", err, InvalidValues)
+	}
+	var ie *ItemError
+	if !errors.As(err, &ie) {
+		t.Fatalf("Error is not an ItemError: %v", err)
+	}
+	if ie.Item != "roster" || !reflect.DeepEqual(ie.Path, []string{"sidebar"}) {
+		t.Errorf("Unexpected location: got %q in %v, want %q in %v", ie.Item, ie.Path, "roster", []string{"sidebar"})
+	}
+	want := "item 'roster' in level sidebar: " + InvalidValues.Error()
+	if got := err.Error(); got != want {
+		t.Errorf("Unexpected message: want %q, got %q", want, got)
+	}
+
+	err = l.HideItem("missing", LayoutHidden)
+	if !errors.As(err, &ie) || ie.Item != "missing" || !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for a missing item: %v", err)
+	}
+
+	// Errors while rendering are attributed to the item they occurred in.
+	g := newTestGui(t, false)
+	if err := l.ResizeItem("sidebar", 0, 70); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	if err := l.AddItem("sidebar", -1, NewFixedItem(30, "status")); err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+	err = l.Layout(g)
+	if !errors.As(err, &ie) || ie.Item != "sidebar" {
+		t.Errorf("Unexpected error for a level that is too small: %v", err)
+	}
+}
+
+func TestLayoutErrorPath(t *testing.T) {
+	g := newTestGui(t, false)
+	failed := errors.New("failed")
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "dashboard", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "charts", WithInner(NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "cpu"),
+				NewRatioItem(1, "memory", WithCreate(func(*gocui.View) error {
+					return failed
+				})),
+			))),
+		))),
+	)
+
+	err := l.Layout(g)
+	if !errors.Is(err, failed) {
+		t.Fatalf("Unexpected error: got %v, want %v", err, failed)
+	}
+	want := "item 'memory' in level dashboard/charts: failed"
+	if got := err.Error(); got != want {
+		t.Errorf("Unexpected message: want %q, got %q", want, got)
+	}
+
+	// Levels too small for their items are reported by the item holding them.
+	l = NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "outer", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "inner", WithInner(NewLevel(LayoutVertical,
+				NewFixedItem(30, "tall"),
+			))),
+		))),
+	)
+	var ie *ItemError
+	err = l.Layout(g)
+	if !errors.As(err, &ie) || ie.Item != "inner" || !reflect.DeepEqual(ie.Path, []string{"outer"}) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestItemErrorSentinels(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+		))),
+	)
+	w := NewWorkspaces()
+	if err := w.Add("main", l); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	wizard := NewWizard(NewFixedItem(3, "header"), NewRatioItem(1, "step1"))
+	split := NewSplitter(LayoutHorizontal, 50, NewRatioItem(1, "left"), NewRatioItem(1, "right"))
+
+	tests := []struct {
+		desc string
+		f    func() error
+		item string
+		path []string
+		err  error
+	}{
+		{
+			desc: "duplicate workspace",
+			f:    func() error { return w.Add("main", NewLevel(LayoutVertical)) },
+			item: "main",
+			err:  DuplicateName,
+		},
+		{
+			desc: "switch to a missing workspace",
+			f:    func() error { return w.Switch("missing") },
+			item: "missing",
+			err:  NotFound,
+		},
+		{
+			desc: "missing workspace",
+			f: func() error {
+				_, err := w.Workspace("missing")
+				return err
+			},
+			item: "missing",
+			err:  NotFound,
+		},
+		{
+			desc: "wizard step out of range",
+			f:    func() error { return wizard.GoTo(1) },
+			item: "_wizardSteps",
+			err:  InvalidValues,
+		},
+		{
+			desc: "invalid split",
+			f:    func() error { return split.SetSplit(0) },
+			item: "left",
+			err:  InvalidValues,
+		},
+		{
+			desc: "merging nothing",
+			f:    func() error { return l.MergeLevel("b", nil) },
+			item: "b",
+			path: []string{"col"},
+			err:  InvalidValues,
+		},
+		{
+			desc: "replacing with nothing",
+			f:    func() error { return NewNamedLevel("root", LayoutVertical).Replace(g, nil) },
+			item: "root",
+			err:  InvalidValues,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.f()
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Unexpected error: got %v, want %v", err, tc.err)
+			}
+			var ie *ItemError
+			if !errors.As(err, &ie) {
+				t.Fatalf("Error is not an ItemError: %v", err)
+			}
+			if ie.Item != tc.item || strings.Join(ie.Path, "/") != strings.Join(tc.path, "/") {
+				t.Errorf("Unexpected location: got %q in %v, want %q in %v", ie.Item, ie.Path, tc.item, tc.path)
+			}
+		})
+	}
+}
+
+func TestContinueOnError(t *testing.T) {
+	g := newTestGui(t, false)
+	failed := errors.New("failed")
+	fail := WithCreate(func(*gocui.View) error {
+		return failed
+	})
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", fail),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c", fail),
+			NewRatioItem(1, "tiny", WithInner(NewLevel(LayoutVertical,
+				NewFixedItem(30, "d"),
+			))),
+		))),
+	)
+	if err := l.ResizeItem("b", 0, 10); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+
+	l.ContinueOnError(true)
+	err := l.Layout(g)
+	if !errors.Is(err, failed) || !errors.Is(err, TooSmall) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("Unexpected errors: %v", err)
+	}
+	for i, want := range []string{
+		"item 'a': failed",
+		"item 'c' in level col: failed",
+		"item 'tiny' in level col: ",
+	} {
+		if got := errs[i].Error(); !strings.HasPrefix(got, want) {
+			t.Errorf("Unexpected error %d: want %q, got %q", i, want, got)
+		}
+	}
+
+	// The rest of the layout is still rendered.
+	checkViews(t, g, map[string]size{
+		"b": {35, 0, 44, 24},
+	})
+}
+
+func TestSetErrorHandler(t *testing.T) {
+	g := newTestGui(t, false)
+	failed := errors.New("failed")
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithCreate(func(*gocui.View) error {
+			return failed
+		})),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewFixedItem(30, "tall"),
+		))),
+		NewRatioItem(1, "b"),
+	)
+
+	var items []string
+	var errs []error
+	l.SetErrorHandler(func(item string, err error) {
+		items = append(items, item)
+		errs = append(errs, err)
+	})
+	if err := l.Layout(g); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if want := []string{"a", "col"}; !reflect.DeepEqual(items, want) {
+		t.Fatalf("Unexpected items: got %v, want %v", items, want)
+	}
+	if errs[0] != failed || !errors.Is(errs[1], TooSmall) {
+		t.Errorf("Unexpected errors handled: %v", errs)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {52, 0, 79, 24},
+	})
+
+	// Without a handler, errors are returned again.
+	l.SetErrorHandler(nil)
+	if err := l.Layout(g); !errors.Is(err, TooSmall) {
+		t.Errorf("Unexpected error: got %v, want %v", err, TooSmall)
+	}
+}
+
+func TestSetItemErrors(t *testing.T) {
+	g := newTestGui(t, false)
+	failed := errors.New("failed")
+	fail := true
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithCreate(func(*gocui.View) error {
+			return failed
+		})),
+		NewRatioItem(1, "b", WithUpdate(func(v *gocui.View) error {
+			if fail {
+				return failed
+			}
+			v.Clear()
+			v.WriteString("fine")
+			return nil
+		})),
+		NewRatioItem(1, "c"),
+	)
+	l.SetItemErrors(ItemErrorsShow)
+	// Update functions are called from the second render.
+	for i := 0; i < 2; i++ {
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 25, 24},
+		"b": {26, 0, 51, 24},
+		"c": {52, 0, 79, 24},
+	})
+	for _, name := range []string{"a", "b"} {
+		if s, _ := l.ItemState(name); s.Err != failed {
+			t.Errorf("Expected %s to have failed, got %v", name, s.Err)
+		}
+		if v, _ := g.View(name); v.Buffer() != "Error: failed" {
+			t.Errorf("Unexpected contents of %s: %q", name, v.Buffer())
+		}
+	}
+	if s, _ := l.ItemState("c"); s.Err != nil {
+		t.Errorf("Unexpected error for c: %v", s.Err)
+	}
+
+	// The error is cleared once the function succeeds.
+	fail = false
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s, _ := l.ItemState("b"); s.Err != nil {
+		t.Errorf("Unexpected error for b: %v", s.Err)
+	}
+	if v, _ := g.View("b"); v.Buffer() != "fine" {
+		t.Errorf("Unexpected contents of b: %q", v.Buffer())
+	}
+
+	// Aborting again, the error is returned.
+	fail = true
+	l.SetItemErrors(ItemErrorsAbort)
+	if err := l.Layout(g); !errors.Is(err, failed) {
+		t.Errorf("Unexpected error: got %v, want %v", err, failed)
+	}
+}
@@ -0,0 +1,220 @@
+package layout
+
+// ItemInfo describes an item of the layout.
+type ItemInfo struct {
+	// The item's name.
+	Name string
+	// Whether the item itself was hidden, with Hidden or HideItem.
+	Hidden HideLayout
+	// Whether the item is displayed. This is false for visible items within
+	// hidden ones, or when all the items within a container are hidden.
+	Visible bool
+	// The item's size, only one of which is set.
+	Ratio int
+	Fixed int
+	// How many levels the item is nested in, starting at 0 for the items of
+	// the layout itself.
+	Depth int
+	// Whether the item contains a level of its own.
+	Container bool
+	// Where the item was last displayed.
+	Rect Rect
+
+	// Only set for the function passed to WithUpdateCtx: whether its view
+	// was just created, and whether it has the focus. Depth is left unset
+	// there.
+	First   bool
+	Focused bool
+}
+
+// FindAll returns the names of all the items for which match returns true, in
+// the order they appear in the layout.
+func (l *layoutLevel) FindAll(match func(*ItemInfo) bool) []string {
+	var names []string
+	l.walk(func(_ *layoutItem, info *ItemInfo) {
+		if match(info) {
+			names = append(names, info.Name)
+		}
+	})
+	return names
+}
+
+// walk calls f for every item in the layout and its sublevels, in order.
+func (l *layoutLevel) walk(f func(*layoutItem, *ItemInfo)) {
+	l.walkLevel(0, l.takeover != nil, f)
+}
+
+func (l *layoutLevel) walkLevel(depth int, forceHidden bool, f func(*layoutItem, *ItemInfo)) {
+	for idx, item := range l.items {
+		hidden := forceHidden || bool(l.hidden(idx))
+		if item.float != nil {
+			// Floating items are displayed over the layout regardless.
+			hidden = bool(item.hidden)
+		}
+		f(item, &ItemInfo{
+			Name:      item.name,
+			Hidden:    item.hidden,
+			Visible:   !hidden,
+			Ratio:     item.ratio,
+			Fixed:     item.fixed,
+			Depth:     depth,
+			Container: item.inner != nil,
+			Rect:      item.rect,
+		})
+		if item.inner != nil {
+			item.inner.walkLevel(depth+1, hidden || item.inner.takeover != nil, f)
+		}
+	}
+}
+
+// State describes an item of the layout, see ItemState.
+type State struct {
+	// Whether the item's view exists, as of the last render. Always false
+	// for items holding a level or a manager.
+	Created bool
+	// Whether the item is displayed, taking into account hidden containers,
+	// carousels, zooming and takeovers.
+	Visible bool
+	// Where the item was last displayed, if it is visible.
+	Rect Rect
+	// Whether the item is zoomed, see Zoom, or floating, see FloatItem.
+	Zoomed   bool
+	Floating bool
+	// The name of the item holding the item's level, or "" for the items of
+	// the layout itself.
+	Level string
+	// The error returned by the item's create or update function the last
+	// time it was called, if kept in the item, see SetItemErrors.
+	Err error
+}
+
+// ItemState returns the state of the named item.
+func (l *layoutLevel) ItemState(name string) (State, error) {
+	item, err := l.findItem(name)
+	if err != nil {
+		return State{}, err
+	}
+	level, err := l.ParentOf(name)
+	if err != nil {
+		return State{}, err
+	}
+
+	s := State{
+		Created:  item.inner == nil && item.manager == nil && l.created[name] && item.saved == nil,
+		Zoomed:   l.zoomed == item,
+		Floating: item.float != nil,
+		Level:    level,
+		Err:      item.err,
+	}
+	if item.manager != nil {
+		l.walk(func(it *layoutItem, info *ItemInfo) {
+			if it == item {
+				s.Visible = info.Visible
+			}
+		})
+	} else {
+		// Items holding a level are displayed if any view within them is.
+		item.viewItems(func(v *layoutItem) {
+			s.Visible = s.Visible || l.displayed(v)
+		})
+	}
+	if s.Visible {
+		s.Rect = item.rect
+	}
+	return s, nil
+}
+
+// VisibleItems returns the names of the views currently displayed by the
+// layout, in the order they appear in it.
+func (l *layoutLevel) VisibleItems() []string {
+	var names []string
+	l.visibleViews(func(item *layoutItem) {
+		names = append(names, item.name)
+	})
+	return names
+}
+
+// ItemAt returns the name of the view the layout displays at the given screen
+// position, as of the last render. Unlike gocui's ViewByPosition, it ignores
+// the views of hidden items, which cover their whole level, and includes the
+// borders of the views. Modals are above the rest of the layout: within one,
+// only its own views are found.
+func (l *layoutLevel) ItemAt(x, y int) (string, bool) {
+	for i := len(l.modals) - 1; i >= 0; i-- {
+		m := l.modals[i].level
+		if r := m.rect; x >= r.X0 && x <= r.X1 && y >= r.Y0 && y <= r.Y1 {
+			return m.ItemAt(x, y)
+		}
+	}
+
+	if item := l.itemAt(x, y); item != nil {
+		return item.name, true
+	}
+	return "", false
+}
+
+// visibleViews calls f for every item displayed as a view, in order, followed
+// by the floating items.
+func (l *layoutLevel) visibleViews(f func(*layoutItem)) {
+	l.tiledViews(f)
+	for _, item := range l.floats {
+		if !item.hidden {
+			f(item)
+		}
+	}
+}
+
+func (l *layoutLevel) tiledViews(f func(*layoutItem)) {
+	if l.takeover != nil {
+		l.takeover.visibleViews(f)
+		return
+	}
+	if z := l.zoomed; z != nil {
+		if z.inner != nil {
+			z.inner.visibleViews(f)
+		} else if z.manager == nil {
+			f(z)
+		}
+		return
+	}
+
+	for idx, item := range l.items {
+		if l.hidden(idx) {
+			continue
+		}
+		if item.inner != nil {
+			item.inner.visibleViews(f)
+		} else if item.manager == nil {
+			f(item)
+		}
+	}
+}
+
+// PathTo returns the names of the items leading to the named item, starting
+// with an item of the layout itself and ending with the named item.
+func (l *layoutLevel) PathTo(name string) ([]string, error) {
+	levels, idx, err := l.findPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make([]string, len(levels))
+	for k, level := range levels {
+		path[k] = level.items[idx[k]].name
+	}
+	return path, nil
+}
+
+// ParentOf returns the name of the item holding the level that contains the
+// named item, or "" if it is an item of the layout itself.
+func (l *layoutLevel) ParentOf(name string) (string, error) {
+	path, err := l.PathTo(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(path) < 2 {
+		return "", nil
+	}
+	return path[len(path)-2], nil
+}
@@ -0,0 +1,222 @@
+package layout
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(10, "a", Hidden()),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+			NewFixedItem(3, "d"),
+		))),
+		NewRatioItem(2, "e", Hidden(), WithInner(NewLevel(LayoutVertical,
+			NewFixedItem(1, "f"),
+		))),
+		NewRatioItem(1, "g", WithInner(NewCarousel(
+			NewRatioItem(1, "h"),
+			NewRatioItem(1, "i"),
+		))),
+	)
+
+	tests := []struct {
+		desc  string
+		match func(*ItemInfo) bool
+		want  string
+	}{
+		{
+			desc:  "hidden fixed items",
+			match: func(i *ItemInfo) bool { return !i.Visible && i.Fixed > 0 },
+			want:  "a f",
+		},
+		{
+			desc:  "hidden items",
+			match: func(i *ItemInfo) bool { return bool(i.Hidden) },
+			want:  "a e",
+		},
+		{
+			desc:  "visible views",
+			match: func(i *ItemInfo) bool { return i.Visible && !i.Container },
+			want:  "c d h",
+		},
+		{
+			desc:  "nested",
+			match: func(i *ItemInfo) bool { return i.Depth > 0 },
+			want:  "c d f h i",
+		},
+		{
+			desc:  "ratio",
+			match: func(i *ItemInfo) bool { return i.Ratio == 2 },
+			want:  "e",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := strings.Join(l.FindAll(tc.match), " "); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestVisibleItems(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(10, "a", Hidden()),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+			NewFixedItem(3, "d"),
+		))),
+		NewRatioItem(1, "e", WithInner(NewLevel(LayoutVertical,
+			NewFixedItem(1, "f", Hidden()),
+		))),
+		NewRatioItem(1, "g", WithInner(NewCarousel(
+			NewRatioItem(1, "h"),
+			NewRatioItem(1, "i"),
+		))),
+	)
+
+	if got, want := strings.Join(l.VisibleItems(), " "), "c d h"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	l.Takeover(NewLevel(LayoutVertical, NewRatioItem(1, "help")))
+	if got, want := strings.Join(l.VisibleItems(), " "), "help"; got != want {
+		t.Errorf("With a takeover: want %q, got %q", want, got)
+	}
+}
+
+func TestPathTo(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(10, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+			NewRatioItem(1, "d", WithInner(NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "e"),
+			))),
+		))),
+	)
+
+	tests := []struct {
+		name   string
+		path   string
+		parent string
+		err    error
+	}{
+		{name: "a", path: "a", parent: ""},
+		{name: "c", path: "b c", parent: "b"},
+		{name: "e", path: "b d e", parent: "d"},
+		{name: "missing", err: NotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := l.PathTo(tc.name)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Unexpected error from PathTo: got %v, want %v", err, tc.err)
+			}
+			if got := strings.Join(path, " "); got != tc.path {
+				t.Errorf("PathTo: want %q, got %q", tc.path, got)
+			}
+
+			parent, err := l.ParentOf(tc.name)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Unexpected error from ParentOf: got %v, want %v", err, tc.err)
+			}
+			if parent != tc.parent {
+				t.Errorf("ParentOf: want %q, got %q", tc.parent, parent)
+			}
+		})
+	}
+}
+
+func TestItemAt(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "hidden", Hidden()),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	l.ShowModal(g, NewLevel(LayoutHorizontal, NewRatioItem(1, "dialog")), 10, 5)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		x, y int
+		want string
+	}{
+		{0, 0, "a"},
+		{39, 24, "a"},
+		{40, 0, "b"},
+		{79, 24, "b"},
+		{35, 10, "dialog"},
+		{44, 14, "dialog"},
+		{80, 0, ""},
+	} {
+		got, ok := l.ItemAt(tc.x, tc.y)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Errorf("ItemAt(%d, %d): got %q, %v, want %q", tc.x, tc.y, got, ok, tc.want)
+		}
+	}
+}
+
+func TestItemState(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c", Hidden()),
+		))),
+		NewRatioItem(1, "d"),
+	)
+	if _, err := l.ItemState("nope"); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error: got %v, want %v", err, NotFound)
+	}
+
+	// Nothing is created before the first render.
+	if got, _ := l.ItemState("a"); got.Created {
+		t.Errorf("Unexpected state before rendering: %+v", got)
+	}
+
+	if err := l.FloatItem("d", 10, 5); err != nil {
+		t.Fatalf("FloatItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	for name, want := range map[string]State{
+		"a":   {Created: true, Visible: true, Rect: Rect{0, 0, 39, 24}},
+		"col": {Visible: true, Rect: Rect{40, 0, 79, 24}},
+		"b":   {Created: true, Visible: true, Rect: Rect{40, 0, 79, 24}, Level: "col"},
+		"c":   {Created: true, Level: "col"},
+		"d":   {Created: true, Visible: true, Rect: Rect{35, 10, 44, 14}, Floating: true},
+	} {
+		got, err := l.ItemState(name)
+		if err != nil {
+			t.Fatalf("ItemState failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("Unexpected state for %q:\ngot  %+v\nwant %+v", name, got, want)
+		}
+	}
+
+	if err := l.Zoom("b"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	if got, _ := l.ItemState("b"); !got.Zoomed || !got.Visible {
+		t.Errorf("Unexpected state for the zoomed item: %+v", got)
+	}
+	if got, _ := l.ItemState("a"); got.Visible {
+		t.Errorf("Unexpected state under the zoomed item: %+v", got)
+	}
+}
@@ -0,0 +1,114 @@
+package layout
+
+import (
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// flashInterval is how long a flashing view stays highlighted, and then not.
+const flashInterval = 150 * time.Millisecond
+
+// flashState is a view being flashed, see Flash.
+type flashState struct {
+	// The highlights and restores left to do, and whether the view is
+	// highlighted.
+	left int
+	on   bool
+
+	// The view's colors before being highlighted.
+	frameColor gocui.Attribute
+	titleColor gocui.Attribute
+}
+
+// SetFlashColor sets the color given to the frame and title of views flashed
+// with Flash, gocui.ColorYellow by default.
+func (l *layoutLevel) SetFlashColor(color gocui.Attribute) {
+	l.flashColor = color
+}
+
+// Flash draws attention to the named view item, such as a background pane
+// with a new message, by highlighting its frame and title the given number
+// of times, restoring their colors in between and afterwards. Flashing a view
+// already flashing makes it flash that many more times from then. Like other
+// changes to views, it is to be called from the gui's goroutine, such as from
+// a key binding or a function passed to the gui's Update.
+func (l *layoutLevel) Flash(g *gocui.Gui, name string, times int) error {
+	if _, err := l.viewItem(name); err != nil {
+		return err
+	}
+	if times < 1 {
+		return l.itemError(name, InvalidValues)
+	}
+
+	if f, ok := l.flashes[name]; ok {
+		f.left = 2 * times
+		if f.on {
+			f.left++
+		}
+		return nil
+	}
+	if l.flashes == nil {
+		l.flashes = make(map[string]*flashState)
+	}
+	l.flashes[name] = &flashState{left: 2 * times}
+	l.flashStep(g, name)
+	return nil
+}
+
+// flashStep highlights or restores the flashing view, and schedules the next
+// step, if any. Flashing stops if the view is gone.
+func (l *layoutLevel) flashStep(g *gocui.Gui, name string) {
+	f, ok := l.flashes[name]
+	if !ok {
+		return
+	}
+	v, err := g.View(name)
+	if err != nil {
+		delete(l.flashes, name)
+		return
+	}
+
+	if f.on {
+		v.FrameColor, v.TitleColor = f.frameColor, f.titleColor
+	} else {
+		f.frameColor, f.titleColor = v.FrameColor, v.TitleColor
+		color := l.flashColor
+		if color == gocui.ColorDefault {
+			color = gocui.ColorYellow
+		}
+		v.FrameColor, v.TitleColor = color, color
+	}
+	f.on = !f.on
+	f.left--
+	if f.left <= 0 {
+		delete(l.flashes, name)
+		return
+	}
+
+	time.AfterFunc(flashInterval, func() {
+		g.Update(func(g *gocui.Gui) error {
+			l.flashStep(g, name)
+			return nil
+		})
+	})
+}
+
+// colors returns the frame and title colors of the view, those it is to get
+// back rather than the highlight if it is flashing.
+func (l *layoutLevel) colors(v *gocui.View) (frame, title gocui.Attribute) {
+	if f, ok := l.flashes[v.Name()]; ok && f.on {
+		return f.frameColor, f.titleColor
+	}
+	return v.FrameColor, v.TitleColor
+}
+
+// setColors sets the frame and title colors of the view, or, while it is
+// highlighted by Flash, those it gets back once the highlight is over.
+func (l *layoutLevel) setColors(v *gocui.View, frame, title gocui.Attribute) {
+	if f, ok := l.flashes[v.Name()]; ok && f.on {
+		f.frameColor, f.titleColor = frame, title
+		return
+	}
+	v.FrameColor, v.TitleColor = frame, title
+}
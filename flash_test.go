@@ -0,0 +1,94 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestFlash(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.Flash(g, "col", 1); !errors.Is(err, InvalidValues) {
+		t.Errorf("Expected InvalidValues flashing a container, got %v", err)
+	}
+	if err := l.Flash(g, "a", 0); !errors.Is(err, InvalidValues) {
+		t.Errorf("Expected InvalidValues flashing no times, got %v", err)
+	}
+
+	v, _ := g.View("a")
+	v.FrameColor = gocui.ColorBlue
+	l.SetFlashColor(gocui.ColorRed)
+	if err := l.Flash(g, "a", 2); err != nil {
+		t.Fatalf("Flash failed: %v", err)
+	}
+	// Steps normally taken on a timer.
+	for i, want := range []gocui.Attribute{gocui.ColorRed, gocui.ColorBlue, gocui.ColorRed, gocui.ColorBlue} {
+		if i > 0 {
+			l.flashStep(g, "a")
+		}
+		if v.FrameColor != want || (want == gocui.ColorRed) != (v.TitleColor == gocui.ColorRed) {
+			t.Errorf("Step %d: unexpected colors %v, %v", i, v.FrameColor, v.TitleColor)
+		}
+	}
+	if len(l.flashes) != 0 {
+		t.Errorf("Expected the flashing to be over")
+	}
+}
+
+func TestFlashFocused(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+	)
+	l.SetFocusStyle(&FocusStyle{FrameColor: gocui.ColorGreen})
+	l.SetFlashColor(gocui.ColorRed)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.SetCurrentView("a"); err != nil {
+		t.Fatalf("SetCurrentView failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	v, _ := g.View("a")
+	if v.FrameColor != gocui.ColorGreen {
+		t.Fatalf("Focus style not applied: %v", v.FrameColor)
+	}
+
+	if err := l.Flash(g, "a", 1); err != nil {
+		t.Fatalf("Flash failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v.FrameColor != gocui.ColorRed {
+		t.Errorf("Expected the flash to show over the focus style, got %v", v.FrameColor)
+	}
+
+	// Losing the focus mid-flash, the view ends up with its own colors.
+	if _, err := g.SetCurrentView("b"); err != nil {
+		t.Fatalf("SetCurrentView failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v.FrameColor != gocui.ColorRed {
+		t.Errorf("Expected a to still be flashing, got %v", v.FrameColor)
+	}
+	l.flashStep(g, "a")
+	if v.FrameColor != gocui.ColorDefault {
+		t.Errorf("Expected a to get its own colors back, got %v", v.FrameColor)
+	}
+}
@@ -0,0 +1,333 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// NotFloating is an error returned when docking an item that isn't floating.
+var NotFloating = fmt.Errorf("Item is not floating")
+
+type floatState struct {
+	w, h int
+
+	// Where the window's top left corner goes, once it was placed rather
+	// than centered.
+	x, y   int
+	placed bool
+}
+
+// FloatItem takes the named view item out of the tiled layout, which shares
+// out its space among the rest of its level, and displays it over the layout
+// instead, centered, w columns wide and h lines high, or as much as fits.
+// The item keeps its view, and its contents, and its place in the tree, to
+// which DockItem returns it. Floating an item that already floats resizes it.
+// Floating windows are displayed in the order they were floated, the last one
+// on top, and come after the rest of the layout in the focus order.
+func (l *layoutLevel) FloatItem(name string, w, h int) error {
+	item, err := l.viewItem(name)
+	if err != nil {
+		return err
+	}
+	if w < 2 || h < 2 {
+		return l.itemError(name, InvalidValues)
+	}
+
+	l.record()
+	if item.float == nil {
+		item.float = &floatState{}
+		l.floats = append(l.floats, item)
+	}
+	item.float.w, item.float.h = w, h
+	l.changed()
+
+	return nil
+}
+
+// DockItem returns a floating item to its place in the tiled layout.
+func (l *layoutLevel) DockItem(name string) error {
+	item, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+	if item.float == nil {
+		return l.itemError(name, NotFloating)
+	}
+
+	l.record()
+	l.dropViews(item.appendHandleNames(nil))
+	item.float = nil
+	l.unfloat(item)
+	l.changed()
+
+	return nil
+}
+
+// Floating returns the names of the floating items, bottom to top.
+func (l *layoutLevel) Floating() []string {
+	var names []string
+	for _, item := range l.floats {
+		names = append(names, item.name)
+	}
+	return names
+}
+
+// unfloat removes the item from the floating windows.
+func (l *layoutLevel) unfloat(item *layoutItem) {
+	for i, f := range l.floats {
+		if f == item {
+			l.floats = append(l.floats[:i:i], l.floats[i+1:]...)
+			return
+		}
+	}
+}
+
+// checkFloats forgets the floating items since removed from the layout.
+func (l *layoutLevel) checkFloats() {
+	for i := len(l.floats) - 1; i >= 0; i-- {
+		item := l.floats[i]
+		if found, err := l.findItem(item.name); err != nil || found != item || item.float == nil {
+			l.unfloat(item)
+		}
+	}
+}
+
+// side is a set of sides of a floating window moved by a drag.
+type side byte
+
+const (
+	sideLeft side = 1 << iota
+	sideTop
+	sideRight
+	sideBottom
+
+	// Moving all the sides moves the whole window.
+	sideAll = sideLeft | sideTop | sideRight | sideBottom
+)
+
+// floatHandles are the parts of a floating window's border that can be
+// dragged, by the suffix of the views over them, and the sides they move. The
+// title bar moves the window, and the edges and corners resize it.
+var floatHandles = []struct {
+	suffix string
+	sides  side
+}{
+	{"~move", sideAll},
+	{"~left", sideLeft},
+	{"~right", sideRight},
+	{"~bottom", sideBottom},
+	{"~topleft", sideTop | sideLeft},
+	{"~topright", sideTop | sideRight},
+	{"~bottomleft", sideBottom | sideLeft},
+	{"~bottomright", sideBottom | sideRight},
+}
+
+// appendHandleNames adds the names of the views over the floating item's
+// border.
+func (l *layoutItem) appendHandleNames(names []string) []string {
+	for _, h := range floatHandles {
+		names = append(names, l.name+h.suffix)
+	}
+	return names
+}
+
+// deleteHandles deletes the views over the border of the floating item with
+// the given name, along with their keybindings, which gocui keeps otherwise.
+func deleteHandles(g *gocui.Gui, name string) error {
+	for _, h := range floatHandles {
+		g.DeleteKeybindings(name + h.suffix)
+		if err := g.DeleteView(name + h.suffix); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleRect returns the part of the border of r moving the given sides.
+func handleRect(r Rect, sides side) Rect {
+	if sides == sideAll {
+		return Rect{r.X0 + 1, r.Y0, r.X1 - 1, r.Y0}
+	}
+
+	h := Rect{r.X0 + 1, r.Y0 + 1, r.X1 - 1, r.Y1 - 1}
+	if sides&sideLeft != 0 {
+		h.X0, h.X1 = r.X0, r.X0
+	}
+	if sides&sideRight != 0 {
+		h.X0, h.X1 = r.X1, r.X1
+	}
+	if sides&sideTop != 0 {
+		h.Y0, h.Y1 = r.Y0, r.Y0
+	}
+	if sides&sideBottom != 0 {
+		h.Y0, h.Y1 = r.Y1, r.Y1
+	}
+	return h
+}
+
+// layoutFloats displays the floating items over the layout, within the given
+// rectangle.
+func (l *layoutLevel) layoutFloats(g *gocui.Gui, x0, y0, x1, y1 int, p *pass) error {
+	for _, item := range l.floats {
+		var err error
+		if item.hidden {
+			item.displayed = false
+			err = item.hiddenView(g, x0, y0, x1, y1, p)
+			if err == nil {
+				err = deleteHandles(g, item.name)
+			}
+		} else {
+			r := item.float.rect(x0, y0, x1, y1)
+			item.rect = r
+			item.displayed = true
+			item.size = r.X1 - r.X0 + 1
+			if err = item.createView(g, r.X0, r.Y0, r.X1, r.Y1, 0, p); err == nil {
+				_, err = g.SetViewOnTop(item.name)
+			}
+			for _, h := range floatHandles {
+				if err == nil {
+					err = l.layoutHandle(g, item, h.suffix, h.sides)
+				}
+			}
+		}
+
+		if p.errs != nil {
+			p.errs.collect(item, len(*p.errs), err)
+		} else if err != nil {
+			return wrapItemError(item, err)
+		}
+	}
+	return nil
+}
+
+// rect returns where the floating window goes within the given rectangle,
+// centered unless placed, and moved and shrunk to fit.
+func (f *floatState) rect(x0, y0, x1, y1 int) Rect {
+	w, h := f.w, f.h
+	if w > x1-x0+1 {
+		w = x1 - x0 + 1
+	}
+	if h > y1-y0+1 {
+		h = y1 - y0 + 1
+	}
+
+	x, y := x0+(x1-x0+1-w)/2, y0+(y1-y0+1-h)/2
+	if f.placed {
+		x, y = f.x, f.y
+		if x+w-1 > x1 {
+			x = x1 - w + 1
+		}
+		if y+h-1 > y1 {
+			y = y1 - h + 1
+		}
+		if x < x0 {
+			x = x0
+		}
+		if y < y0 {
+			y = y0
+		}
+	}
+	return Rect{x, y, x + w - 1, y + h - 1}
+}
+
+// layoutHandle places an invisible view over the part of the floating item's
+// border moving the given sides, filled with blanks so that the position of a
+// click can be read back from its cursor.
+func (l *layoutLevel) layoutHandle(g *gocui.Gui, item *layoutItem, suffix string, sides side) error {
+	h, name := handleRect(item.rect, sides), item.name+suffix
+	v, err := g.SetView(name, h.X0-1, h.Y0-1, h.X1+1, h.Y1+1, 0)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Visible = false
+		v.Frame = false
+		if err := g.SetKeybinding(name, gocui.MouseLeft, gocui.ModNone,
+			func(g *gocui.Gui, v *gocui.View) error {
+				h := handleRect(item.rect, sides)
+				cx, cy := v.Cursor()
+				return l.startFloatDrag(g, item, sides, h.X0+cx, h.Y0+cy)
+			}); err != nil {
+			return err
+		}
+	}
+
+	blank := strings.Repeat(strings.Repeat(" ", h.X1-h.X0+1)+"\n", h.Y1-h.Y0+1)
+	if v.Buffer() != blank {
+		v.Clear()
+		v.WriteString(blank)
+	}
+
+	_, err = g.SetViewOnTop(name)
+	return err
+}
+
+// startFloatDrag picks up the given sides of the floating item, clicked at
+// x, y, recording the state before the drag. They follow the mouse until the
+// next click.
+func (l *layoutLevel) startFloatDrag(g *gocui.Gui, item *layoutItem, sides side, x, y int) error {
+	l.record()
+	from := item.rect
+	drag := func(mx, my int) error {
+		l.dragFloat(item, from, sides, mx-x, my-y)
+		return nil
+	}
+	return startCapture(g, drag, drag)
+}
+
+// dragFloat moves the given sides of the floating item, which was in from,
+// by dx and dy, keeping the window at least two columns wide and two lines
+// high, and within the layout.
+func (l *layoutLevel) dragFloat(item *layoutItem, from Rect, sides side, dx, dy int) {
+	if item.float == nil {
+		return
+	}
+
+	b, r := l.rect, from
+	if sides == sideAll {
+		r = Rect{r.X0 + dx, r.Y0 + dy, r.X1 + dx, r.Y1 + dy}
+		if r.X1 > b.X1 {
+			r.X0, r.X1 = r.X0-(r.X1-b.X1), b.X1
+		}
+		if r.Y1 > b.Y1 {
+			r.Y0, r.Y1 = r.Y0-(r.Y1-b.Y1), b.Y1
+		}
+		if r.X0 < b.X0 {
+			r.X0, r.X1 = b.X0, r.X1+(b.X0-r.X0)
+		}
+		if r.Y0 < b.Y0 {
+			r.Y0, r.Y1 = b.Y0, r.Y1+(b.Y0-r.Y0)
+		}
+	} else {
+		if sides&sideLeft != 0 {
+			r.X0 = clamp(r.X0+dx, b.X0, r.X1-1)
+		}
+		if sides&sideRight != 0 {
+			r.X1 = clamp(r.X1+dx, r.X0+1, b.X1)
+		}
+		if sides&sideTop != 0 {
+			r.Y0 = clamp(r.Y0+dy, b.Y0, r.Y1-1)
+		}
+		if sides&sideBottom != 0 {
+			r.Y1 = clamp(r.Y1+dy, r.Y0+1, b.Y1)
+		}
+	}
+
+	f := item.float
+	f.x, f.y, f.placed = r.X0, r.Y0, true
+	f.w, f.h = r.X1-r.X0+1, r.Y1-r.Y0+1
+	l.changed()
+}
+
+// clamp returns v, or the nearest of min and max if outside them.
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
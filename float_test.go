@@ -0,0 +1,171 @@
+package layout
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestFloatItem(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithCreate(func(v *gocui.View) error {
+			_, err := v.Write([]byte("kept"))
+			return err
+		})),
+		NewRatioItem(1, "c", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "d"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.FloatItem("c", 10, 10); !errors.Is(err, InvalidValues) {
+		t.Errorf("Expected InvalidValues floating a container, got %v", err)
+	}
+	if err := l.DockItem("b"); !errors.Is(err, NotFloating) {
+		t.Errorf("Expected NotFloating docking a tiled item, got %v", err)
+	}
+
+	if err := l.FloatItem("b", 30, 10); err != nil {
+		t.Fatalf("FloatItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 39, 24},
+		"b": {25, 7, 54, 16},
+		"d": {40, 0, 79, 24},
+	})
+	if v, _ := g.View("b"); v.Buffer() != "kept" {
+		t.Errorf("Expected the view contents to be kept, got %q", v.Buffer())
+	}
+	if got, want := l.VisibleItems(), []string{"a", "d", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected visible items: got %v, want %v", got, want)
+	}
+	if name, _ := l.ItemAt(30, 10); name != "b" {
+		t.Errorf("Expected the floating item at 30,10, got %q", name)
+	}
+	if got := l.Floating(); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Unexpected floating items: %v", got)
+	}
+
+	// Floating windows are shrunk to fit.
+	if err := l.FloatItem("b", 100, 10); err != nil {
+		t.Fatalf("FloatItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{"b": {0, 7, 79, 16}})
+
+	if err := l.DockItem("b"); err != nil {
+		t.Fatalf("DockItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 25, 24},
+		"b": {26, 0, 51, 24},
+		"d": {52, 0, 79, 24},
+	})
+	if v, _ := g.View("b"); v.Buffer() != "kept" {
+		t.Errorf("Expected the view contents to be kept, got %q", v.Buffer())
+	}
+
+	// Docking can be undone.
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{"b": {0, 7, 79, 16}})
+
+	// Removed items no longer float.
+	if err := l.RemoveItem("b"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if len(l.floats) != 0 {
+		t.Errorf("Expected no floating items, got %v", l.Floating())
+	}
+}
+
+func TestDragFloat(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+	)
+	if err := l.FloatItem("b", 20, 10); err != nil {
+		t.Fatalf("FloatItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b":             {30, 7, 49, 16},
+		"b~move":        {30, 6, 49, 8},
+		"b~right":       {48, 7, 50, 16},
+		"b~bottomright": {48, 15, 50, 17},
+	})
+	if v, _ := g.View("b~move"); v.Buffer() != strings.Repeat(" ", 18)+"\n" {
+		t.Errorf("Unexpected title bar contents: %q", v.Buffer())
+	}
+
+	item := l.items[1]
+	if err := l.startFloatDrag(g, item, sideAll, 35, 7); err != nil {
+		t.Fatalf("startFloatDrag failed: %v", err)
+	}
+	for _, tc := range []struct {
+		sides  side
+		dx, dy int
+		want   size
+	}{
+		// Moved, then kept within the screen.
+		{sideAll, -10, 5, size{20, 12, 39, 21}},
+		{sideAll, 100, 100, size{60, 15, 79, 24}},
+		{sideAll, -100, -100, size{0, 0, 19, 9}},
+		// Resized, down to two columns and lines.
+		{sideBottom | sideRight, 5, 2, size{30, 7, 54, 18}},
+		{sideLeft, 100, 0, size{48, 7, 49, 16}},
+		{sideTop | sideLeft, -100, -100, size{0, 0, 49, 16}},
+		{sideRight, 100, 0, size{30, 7, 79, 16}},
+	} {
+		l.dragFloat(item, Rect{30, 7, 49, 16}, tc.sides, tc.dx, tc.dy)
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+		checkViews(t, g, map[string]size{"b": tc.want})
+	}
+
+	// The whole drag is undone at once.
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{"b": {30, 7, 49, 16}})
+
+	// Docked, the handles are deleted.
+	if err := l.DockItem("b"); err != nil {
+		t.Fatalf("DockItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if viewExists(g, "b~move") {
+		t.Errorf("Expected the handles to be deleted once docked")
+	}
+}
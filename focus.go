@@ -0,0 +1,276 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Unfocusable is an error returned when focusing an item that isn't displayed
+// as a view, such as a hidden item or one holding a level, or that was made
+// NotFocusable.
+var Unfocusable = fmt.Errorf("Item can't be focused")
+
+// FocusNext focuses the view after the gui's current one, in the order the
+// items appear in the layout, wrapping around at the end. Only views that are
+// displayed are considered. If the current view isn't one of them, the first
+// is focused.
+func (l *layoutLevel) FocusNext(g *gocui.Gui) error {
+	return l.focusStep(g, 1)
+}
+
+// FocusPrev focuses the view before the gui's current one, the same way as
+// FocusNext.
+func (l *layoutLevel) FocusPrev(g *gocui.Gui) error {
+	return l.focusStep(g, -1)
+}
+
+// Focus makes the named item's view the gui's current view, and raises it
+// above the others, when the layout is next rendered.
+func (l *layoutLevel) Focus(name string) error {
+	item, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+	if !item.focusable() || !l.displayed(item) {
+		return l.itemError(name, Unfocusable)
+	}
+
+	l.focusName = item.name
+	return nil
+}
+
+// WithTabIndex sets the item's place in the focus order of FocusNext and
+// FocusPrev. Items with an index come first, from the lowest, followed by
+// those without one in the order they appear in the layout.
+func WithTabIndex(n int) layoutItemOption {
+	return func(l *layoutItem) {
+		l.tabIndex = n
+		l.hasTabIndex = true
+	}
+}
+
+// NotFocusable excludes the item from the focus manager, such as for headers,
+// separators and status lines. It is skipped by FocusNext and FocusPrev, and
+// isn't focused by the mouse.
+func NotFocusable() layoutItemOption {
+	return func(l *layoutItem) {
+		l.notFocusable = true
+	}
+}
+
+// focusable reports if the item can be given the focus when displayed.
+func (l *layoutItem) focusable() bool {
+	return l.inner == nil && l.manager == nil && !l.notFocusable
+}
+
+// WithInitialFocus focuses the item once the layout is first rendered, and
+// when switching to its workspace.
+func WithInitialFocus() layoutItemOption {
+	return func(l *layoutItem) {
+		l.initialFocus = true
+	}
+}
+
+// WithOnFocus calls f with the item's view when the focus manager focuses it.
+func WithOnFocus(f func(*gocui.View) error) layoutItemOption {
+	return func(l *layoutItem) {
+		l.fFocus = f
+	}
+}
+
+// WithOnBlur calls f with the item's view when the focus manager moves the
+// focus away from it.
+func WithOnBlur(f func(*gocui.View) error) layoutItemOption {
+	return func(l *layoutItem) {
+		l.fBlur = f
+	}
+}
+
+// focusOrder returns the items that can be focused, in order.
+func (l *layoutLevel) focusOrder() []*layoutItem {
+	var items []*layoutItem
+	l.visibleViews(func(item *layoutItem) {
+		if item.focusable() {
+			items = append(items, item)
+		}
+	})
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.hasTabIndex != b.hasTabIndex {
+			return a.hasTabIndex
+		}
+		return a.tabIndex < b.tabIndex
+	})
+	return items
+}
+
+// displayed reports if the item is currently displayed as a view.
+func (l *layoutLevel) displayed(item *layoutItem) bool {
+	found := false
+	l.visibleViews(func(i *layoutItem) {
+		if i == item {
+			found = true
+		}
+	})
+	return found
+}
+
+func (l *layoutLevel) focusStep(g *gocui.Gui, step int) error {
+	if m := l.topModal(); m != nil {
+		return m.level.focusStep(g, step)
+	}
+
+	items := l.focusOrder()
+	if len(items) == 0 {
+		return nil
+	}
+
+	next := 0
+	if v := g.CurrentView(); v != nil {
+		for idx, item := range items {
+			if item.name == v.Name() {
+				next = (idx + step + len(items)) % len(items)
+				break
+			}
+		}
+	}
+
+	return l.focus(g, items[next])
+}
+
+// applyFocus focuses the view requested with Focus, or the one marked with
+// WithInitialFocus on the first render, once it was rendered.
+func (l *layoutLevel) applyFocus(g *gocui.Gui) error {
+	if !l.focusedInitially {
+		l.focusedInitially = true
+		l.viewItems(func(item *layoutItem) {
+			if item.initialFocus && l.focusName == "" {
+				l.focusName = item.name
+			}
+		})
+	}
+	if l.focusName == "" {
+		return nil
+	}
+
+	name := l.focusName
+	l.focusName = ""
+	item, err := l.findItem(name)
+	if err != nil || !l.displayed(item) {
+		return nil
+	}
+	return l.focus(g, item)
+}
+
+// focus makes the item's view current, and raises it, calling the blur
+// function of the item losing the focus and the focus function of the item.
+func (l *layoutLevel) focus(g *gocui.Gui, item *layoutItem) error {
+	prev := g.CurrentView()
+	if prev != nil && prev.Name() == item.name {
+		_, err := g.SetViewOnTop(item.name)
+		return err
+	}
+
+	if prev != nil {
+		if p, err := l.findItem(prev.Name()); err == nil && p.fBlur != nil && p.inner == nil {
+			if err := call(g, l.deferCallbacks, p, func() error {
+				return p.fBlur(prev)
+			}); err != nil {
+				return l.itemError(p.name, err)
+			}
+		}
+	}
+
+	v, err := g.SetCurrentView(item.name)
+	if err != nil {
+		return err
+	}
+	if _, err := g.SetViewOnTop(item.name); err != nil {
+		return err
+	}
+	if item.fFocus != nil {
+		if err := call(g, l.deferCallbacks, item, func() error {
+			return item.fFocus(v)
+		}); err != nil {
+			return l.itemError(item.name, err)
+		}
+	}
+	return nil
+}
+
+// FocusStyle is how the view with the focus is set apart from the others.
+type FocusStyle struct {
+	// The colors of the focused view's frame and title.
+	FrameColor gocui.Attribute
+	TitleColor gocui.Attribute
+	// Added around the focused view's title.
+	TitlePrefix, TitleSuffix string
+}
+
+// focusStyled is the view styled as focused, and its settings beforehand.
+type focusStyled struct {
+	name       string
+	frameColor gocui.Attribute
+	titleColor gocui.Attribute
+	title      string
+	decorated  string
+}
+
+// SetFocusStyle applies the style to the layout's view that has the focus,
+// whether focused with FocusNext or otherwise, restoring its previous colors
+// and title once it loses the focus. The style is applied when the layout is
+// rendered. A nil style turns this off.
+func (l *layoutLevel) SetFocusStyle(style *FocusStyle) {
+	l.focusStyle = style
+}
+
+// styleFocus applies the focus style to the current view, if it belongs to the
+// layout, and removes it from the view that had the focus before. The colors
+// of views highlighted by Flash are those they get back afterwards.
+func (l *layoutLevel) styleFocus(g *gocui.Gui) {
+	var cur *gocui.View
+	if l.focusStyle != nil {
+		if v := g.CurrentView(); v != nil {
+			if item, err := l.findItem(v.Name()); err == nil && item.inner == nil && l.displayed(item) {
+				cur = v
+			}
+		}
+	}
+
+	s := &l.focusStyled
+	if s.name != "" && (cur == nil || cur.Name() != s.name) {
+		if v, err := g.View(s.name); err == nil {
+			l.setColors(v, s.frameColor, s.titleColor)
+			if v.Title == s.decorated {
+				v.Title = s.title
+			}
+		}
+		*s = focusStyled{}
+	}
+	if cur == nil {
+		return
+	}
+
+	if s.name == "" {
+		frame, title := l.colors(cur)
+		*s = focusStyled{
+			name:       cur.Name(),
+			frameColor: frame,
+			titleColor: title,
+			title:      cur.Title,
+		}
+	} else if cur.Title != s.decorated {
+		// The title was changed since, such as by WithTitle.
+		s.title = cur.Title
+	}
+
+	style := l.focusStyle
+	l.setColors(cur, style.FrameColor, style.TitleColor)
+	s.decorated = s.title
+	if s.title != "" {
+		s.decorated = style.TitlePrefix + s.title + style.TitleSuffix
+	}
+	cur.Title = s.decorated
+}
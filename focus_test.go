@@ -0,0 +1,278 @@
+package layout
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestFocusCycle(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "hidden", Hidden()),
+			NewRatioItem(1, "c"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	steps := []struct {
+		next bool
+		want string
+	}{
+		{true, "a"},
+		{true, "b"},
+		{true, "c"},
+		{true, "a"},
+		{false, "c"},
+		{false, "b"},
+	}
+	for _, s := range steps {
+		var err error
+		if s.next {
+			err = l.FocusNext(g)
+		} else {
+			err = l.FocusPrev(g)
+		}
+		if err != nil {
+			t.Fatalf("Focus failed: %v", err)
+		}
+		v := g.CurrentView()
+		if v == nil || v.Name() != s.want {
+			t.Fatalf("Expected %q to be focused, got %v", s.want, v)
+		}
+		if top := g.Views()[len(g.Views())-1]; top != v {
+			t.Errorf("Expected %q on top, got %q", s.want, top.Name())
+		}
+	}
+}
+
+func TestFocus(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "hidden", Hidden()),
+	)
+
+	if err := l.Focus("b"); err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v := g.CurrentView(); v == nil || v.Name() != "b" {
+		t.Errorf("Expected b to be focused, got %v", v)
+	}
+
+	if err := l.Focus("hidden"); !errors.Is(err, Unfocusable) {
+		t.Errorf("Expected Unfocusable, got %v", err)
+	}
+	if err := l.Focus("missing"); !errors.Is(err, NotFound) {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestWithTabIndex(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithTabIndex(2)),
+		NewRatioItem(1, "c"),
+		NewRatioItem(1, "d", WithTabIndex(1)),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	for _, want := range []string{"d", "b", "a", "c", "d"} {
+		if err := l.FocusNext(g); err != nil {
+			t.Fatalf("FocusNext failed: %v", err)
+		}
+		if v := g.CurrentView(); v == nil || v.Name() != want {
+			t.Fatalf("Expected %q to be focused, got %v", want, v)
+		}
+	}
+}
+
+func TestOnFocus(t *testing.T) {
+	g := newTestGui(t, false)
+	var events []string
+	track := func(name string) []layoutItemOption {
+		return []layoutItemOption{
+			WithOnFocus(func(v *gocui.View) error {
+				events = append(events, "focus "+v.Name())
+				return nil
+			}),
+			WithOnBlur(func(v *gocui.View) error {
+				events = append(events, "blur "+v.Name())
+				return nil
+			}),
+		}
+	}
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", track("a")...),
+		NewRatioItem(1, "b", track("b")...),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := l.FocusNext(g); err != nil {
+			t.Fatalf("FocusNext failed: %v", err)
+		}
+	}
+	if err := l.Focus("b"); err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	want := []string{"focus a", "blur a", "focus b"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Unexpected events: want %v, got %v", want, events)
+	}
+}
+
+func TestSetFocusStyle(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithTitle("A")),
+		NewRatioItem(1, "b", WithCreate(func(v *gocui.View) error {
+			v.FrameColor = gocui.ColorBlue
+			return nil
+		})),
+	)
+	l.SetFocusStyle(&FocusStyle{
+		FrameColor:  gocui.ColorGreen,
+		TitleColor:  gocui.ColorYellow,
+		TitlePrefix: "[",
+		TitleSuffix: "]",
+	})
+
+	check := func(name, title string, frame, titleColor gocui.Attribute) {
+		t.Helper()
+		v, err := g.View(name)
+		if err != nil {
+			t.Fatalf("View %q not found: %v", name, err)
+		}
+		if v.Title != title || v.FrameColor != frame || v.TitleColor != titleColor {
+			t.Errorf("Unexpected style for %q: title %q, frame %v, title color %v",
+				name, v.Title, v.FrameColor, v.TitleColor)
+		}
+	}
+
+	if err := l.Focus("a"); err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	check("a", "[A]", gocui.ColorGreen, gocui.ColorYellow)
+	check("b", "", gocui.ColorBlue, gocui.ColorDefault)
+
+	if err := l.FocusNext(g); err != nil {
+		t.Fatalf("FocusNext failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	check("a", "A", gocui.ColorDefault, gocui.ColorDefault)
+	check("b", "", gocui.ColorGreen, gocui.ColorYellow)
+
+	l.SetFocusStyle(nil)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	check("b", "", gocui.ColorBlue, gocui.ColorDefault)
+}
+
+func TestNotFocusable(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewFixedItem(3, "header", NotFocusable()),
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewFixedItem(3, "status", NotFocusable()),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	for _, want := range []string{"a", "b", "a"} {
+		if err := l.FocusNext(g); err != nil {
+			t.Fatalf("FocusNext failed: %v", err)
+		}
+		if v := g.CurrentView(); v == nil || v.Name() != want {
+			t.Fatalf("Expected %q to be focused, got %v", want, v)
+		}
+	}
+
+	if err := l.Focus("header"); !errors.Is(err, Unfocusable) {
+		t.Errorf("Expected Unfocusable, got %v", err)
+	}
+	v, err := g.View("status")
+	if err != nil {
+		t.Fatalf("View status not found: %v", err)
+	}
+	if err := l.mouseFocus(g, v); err != nil {
+		t.Fatalf("mouseFocus failed: %v", err)
+	}
+	if cur := g.CurrentView(); cur == nil || cur.Name() != "a" {
+		t.Errorf("Expected a to keep the focus, got %v", cur)
+	}
+}
+
+func TestWithInitialFocus(t *testing.T) {
+	g := newTestGui(t, false)
+	w := NewWorkspaces()
+	one := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithInitialFocus()),
+	)
+	two := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "c", WithInitialFocus()),
+		NewRatioItem(1, "d"),
+	)
+	if err := w.Add("one", one); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := w.Add("two", two); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	focused := func(want string) {
+		t.Helper()
+		if err := w.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+		if v := g.CurrentView(); v == nil || v.Name() != want {
+			t.Errorf("Expected %q to be focused, got %v", want, v)
+		}
+	}
+
+	focused("b")
+	if err := one.FocusNext(g); err != nil {
+		t.Fatalf("FocusNext failed: %v", err)
+	}
+	focused("a")
+
+	if err := w.Switch("two"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	focused("c")
+	if err := w.Switch("one"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	focused("b")
+}
@@ -2,4 +2,7 @@ module github.com/zigdon/gocui-relative-layout
 
 go 1.15
 
-require github.com/awesome-gocui/gocui v1.0.1
+require (
+	github.com/awesome-gocui/gocui v1.0.1
+	gopkg.in/yaml.v2 v2.4.0
+)
@@ -0,0 +1,181 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// helpView is the name of the view listing the layout's keys, see ShowHelp.
+const helpView = "_layoutHelp"
+
+// helpEntry is a key bound by the layout, and what it does.
+type helpEntry struct {
+	Binding
+	desc string
+}
+
+// actionHelp describes the actions bound by BindDefaults.
+var actionHelp = map[Action]string{
+	ActionFocusNext: "Focus the next view",
+	ActionFocusPrev: "Focus the previous view",
+	ActionZoom:      "Zoom or unzoom the focused item",
+	ActionEqualize:  "Equalize the focused item's level",
+	ActionResize:    "Resize the focused item",
+}
+
+// keyNames are the names of the keys that aren't runes, for ShowHelp.
+var keyNames = map[gocui.Key]string{
+	gocui.KeyTab:            "Tab",
+	gocui.KeyEnter:          "Enter",
+	gocui.KeyEsc:            "Esc",
+	gocui.KeySpace:          "Space",
+	gocui.KeyBackspace:      "Backspace",
+	gocui.KeyBackspace2:     "Backspace",
+	gocui.KeyInsert:         "Insert",
+	gocui.KeyDelete:         "Delete",
+	gocui.KeyHome:           "Home",
+	gocui.KeyEnd:            "End",
+	gocui.KeyPgup:           "PgUp",
+	gocui.KeyPgdn:           "PgDn",
+	gocui.KeyArrowUp:        "Up",
+	gocui.KeyArrowDown:      "Down",
+	gocui.KeyArrowLeft:      "Left",
+	gocui.KeyArrowRight:     "Right",
+	gocui.MouseLeft:         "Click",
+	gocui.MouseMiddle:       "Middle-click",
+	gocui.MouseRight:        "Right-click",
+	gocui.MouseWheelUp:      "Wheel up",
+	gocui.MouseWheelDown:    "Wheel down",
+	gocui.KeyF1:             "F1",
+	gocui.KeyF2:             "F2",
+	gocui.KeyF3:             "F3",
+	gocui.KeyF4:             "F4",
+	gocui.KeyF5:             "F5",
+	gocui.KeyF6:             "F6",
+	gocui.KeyF7:             "F7",
+	gocui.KeyF8:             "F8",
+	gocui.KeyF9:             "F9",
+	gocui.KeyF10:            "F10",
+	gocui.KeyF11:            "F11",
+	gocui.KeyF12:            "F12",
+	gocui.KeyCtrlSpace:      "Ctrl-Space",
+	gocui.KeyCtrlBackslash:  "Ctrl-\\",
+	gocui.KeyCtrlRsqBracket: "Ctrl-]",
+	gocui.KeyCtrlUnderscore: "Ctrl-_",
+}
+
+// String returns the name of the key, such as "Alt-z" or "Ctrl-W".
+func (b Binding) String() string {
+	var name string
+	switch k := b.Key.(type) {
+	case rune:
+		name = string(k)
+		if k == ' ' {
+			name = "Space"
+		}
+	case gocui.Key:
+		var ok bool
+		if name, ok = keyNames[k]; !ok {
+			if k >= gocui.KeyCtrlA && k <= gocui.KeyCtrlZ {
+				name = fmt.Sprintf("Ctrl-%c", 'A'+rune(k-gocui.KeyCtrlA))
+			} else {
+				name = fmt.Sprintf("Key %d", k)
+			}
+		}
+	default:
+		name = fmt.Sprint(k)
+	}
+
+	if b.Mod == gocui.ModAlt {
+		name = "Alt-" + name
+	}
+	return name
+}
+
+// bindHelp binds key, with mod, for all views, to handler, listing it in
+// ShowHelp as desc.
+func (l *layoutLevel) bindHelp(g *gocui.Gui, key interface{}, mod gocui.Modifier, desc string,
+	handler func(*gocui.Gui, *gocui.View) error) error {
+	if err := g.SetKeybinding("", key, mod, handler); err != nil {
+		return err
+	}
+	l.help = append(l.help, helpEntry{Binding{key, mod}, desc})
+	return nil
+}
+
+// helpEntries returns the keys bound through the layout, in the order they
+// were bound, followed by those of the displayed items, see WithKeybinding.
+func (l *layoutLevel) helpEntries() []helpEntry {
+	entries := append([]helpEntry(nil), l.help...)
+	l.viewItems(func(item *layoutItem) {
+		if !item.displayed {
+			return
+		}
+		for _, k := range item.keys {
+			entries = append(entries, helpEntry{Binding{k.key, k.mod}, "In " + item.name})
+		}
+	})
+	return entries
+}
+
+// ShowHelp displays, as a modal, the keys bound through the layout, with
+// BindDefaults, BindZoom, BindClose and WithKeybinding, and what they do. Any
+// key closes it.
+func (l *layoutLevel) ShowHelp(g *gocui.Gui) {
+	entries := l.helpEntries()
+	keys := make([]string, len(entries))
+	width := 0
+	for i, e := range entries {
+		keys[i] = e.String()
+		if n := len([]rune(keys[i])); n > width {
+			width = n
+		}
+	}
+
+	lines := make([]string, len(entries))
+	longest := 0
+	for i, e := range entries {
+		lines[i] = keys[i] + strings.Repeat(" ", width-len([]rune(keys[i]))) + "  " + e.desc
+		if n := len([]rune(lines[i])); n > longest {
+			longest = n
+		}
+	}
+	if len(lines) == 0 {
+		lines = []string{"No keys bound"}
+		longest = len(lines[0])
+	}
+
+	dismiss := func(*gocui.Gui, *gocui.View) error {
+		l.CloseModal()
+		return nil
+	}
+	// Rune keys go to the editor of the editable view, and keys bound for
+	// all views are taken over by its own bindings.
+	opts := []layoutItemOption{
+		WithTitle("Keys"),
+		WithCreate(func(v *gocui.View) error {
+			v.Editable = true
+			v.Editor = gocui.EditorFunc(func(*gocui.View, gocui.Key, rune, gocui.Modifier) {
+				l.CloseModal()
+			})
+			v.WriteString(strings.Join(lines, "\n"))
+			return nil
+		}),
+		WithKeybinding(gocui.MouseLeft, gocui.ModNone, dismiss),
+	}
+	for _, e := range entries {
+		opts = append(opts, WithKeybinding(e.Key, e.Mod, dismiss))
+	}
+
+	l.ShowModal(g, NewLevel(LayoutVertical, NewRatioItem(1, helpView, opts...)), longest+2, len(lines)+2)
+}
+
+// BindHelp binds key, with mod, for all views, to ShowHelp.
+func (l *layoutLevel) BindHelp(g *gocui.Gui, key interface{}, mod gocui.Modifier) error {
+	return l.bindHelp(g, key, mod, "Show this help", func(g *gocui.Gui, _ *gocui.View) error {
+		l.ShowHelp(g)
+		return nil
+	})
+}
@@ -0,0 +1,65 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestShowHelp(t *testing.T) {
+	g := newTestGui(t, false)
+	handler := func(*gocui.Gui, *gocui.View) error { return nil }
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithKeybinding(gocui.KeyCtrlS, gocui.ModNone, handler)),
+		NewRatioItem(1, "b", Hidden(), WithKeybinding('x', gocui.ModNone, handler)),
+	)
+	if err := l.BindDefaults(g,
+		BindKey(ActionEqualize, nil, gocui.ModNone),
+		BindToggle(gocui.KeyF2, gocui.ModNone, "b"),
+	); err != nil {
+		t.Fatalf("BindDefaults failed: %v", err)
+	}
+	if err := l.BindHelp(g, '?', gocui.ModNone); err != nil {
+		t.Fatalf("BindHelp failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	l.ShowHelp(g)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	v, err := g.View(helpView)
+	if err != nil {
+		t.Fatalf("Help not displayed: %v", err)
+	}
+	want := []string{
+		"Tab      Focus the next view",
+		"Alt-Tab  Focus the previous view",
+		"Alt-z    Zoom or unzoom the focused item",
+		"Alt-r    Resize the focused item",
+		"F2       Show or hide b",
+		"?        Show this help",
+		"Ctrl-S   In a",
+	}
+	if got := v.Buffer(); got != strings.Join(want, "\n") {
+		t.Errorf("Unexpected help:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	if x0, y0, x1, y1 := v.Dimensions(); x1-x0 != 41 || y1-y0 != 8 {
+		t.Errorf("Unexpected help size: %d,%d-%d,%d", x0, y0, x1, y1)
+	}
+	if cur := g.CurrentView(); cur == nil || cur.Name() != helpView {
+		t.Errorf("Expected the help to be focused, got %v", cur)
+	}
+
+	// Any key closes it.
+	v.Editor.Edit(v, 0, 'q', gocui.ModNone)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if viewExists(g, helpView) {
+		t.Errorf("Expected the help to be closed")
+	}
+}
@@ -0,0 +1,75 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// HiddenViews selects what is done with the views of hidden items.
+type HiddenViews int
+
+const (
+	// HiddenBelow creates the views of hidden items over their level, below
+	// all other views.
+	HiddenBelow HiddenViews = iota
+	// HiddenOffscreen creates the views of hidden items outside the screen,
+	// so they can't be found by their position, such as by mouse handlers.
+	HiddenOffscreen
+	// HiddenDeleted does not create views for hidden items, and deletes
+	// their views when they are hidden. Their contents and settings are
+	// restored when the items are shown again.
+	HiddenDeleted
+)
+
+// SetHiddenViews sets what is done with the views of hidden items. By default,
+// they are created below the visible views (HiddenBelow), so that their
+// contents and create and update functions are kept up to date.
+func (l *layoutLevel) SetHiddenViews(mode HiddenViews) {
+	l.hiddenViews = mode
+}
+
+// hiddenView creates or deletes the view of a hidden item within the given
+// rectangle, according to mode.
+func (l *layoutItem) hiddenView(g *gocui.Gui, x0, y0, x1, y1 int, p *pass) error {
+	// Shown again, the view appears directly where it goes, unless it
+	// slides in.
+	l.anim = animation{hidden: bool(l.hidden)}
+	switch p.hidden {
+	case HiddenOffscreen:
+		return l.createView(g, -3, -3, -1, -1, 0, p)
+	case HiddenDeleted:
+		if err := l.migrateView(g); err != nil {
+			return err
+		}
+		v, err := g.View(l.name)
+		if err != nil {
+			return nil
+		}
+		l.saved = v
+		return deleteView(g, l.name, l.fDestroy, p.deferred)
+	}
+
+	if err := l.createView(g, x0, y0, x1, y1, 0, p); err != nil {
+		return err
+	}
+	g.SetViewOnBottom(l.name)
+	return nil
+}
+
+// restoreView recreates the view deleted when the item was hidden, with the
+// same contents and settings. The item's create function is not called again.
+func (l *layoutItem) restoreView(g *gocui.Gui, x0, y0, x1, y1 int, overlaps byte) error {
+	if l.saved == nil {
+		return nil
+	}
+
+	saved := l.saved
+	l.saved = nil
+
+	v, err := g.SetView(l.name, x0, y0, x1, y1, overlaps)
+	if err == gocui.ErrUnknownView {
+		copyView(v, saved)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package layout
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestSetHiddenViews(t *testing.T) {
+	tests := []struct {
+		desc   string
+		mode   HiddenViews
+		exists bool
+		hidden size
+	}{
+		{"below", HiddenBelow, true, size{0, 0, 79, 24}},
+		{"offscreen", HiddenOffscreen, true, size{-3, -3, -1, -1}},
+		{"deleted", HiddenDeleted, false, size{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := newTestGui(t, false)
+			l := NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "a"),
+				NewRatioItem(1, "b"),
+			)
+			l.SetHiddenViews(tc.mode)
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+
+			if err := l.HideItem("b", LayoutHidden); err != nil {
+				t.Fatalf("HideItem failed: %v", err)
+			}
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			checkViews(t, g, map[string]size{
+				"a": {0, 0, 79, 24},
+			})
+			if got := viewExists(g, "b"); got != tc.exists {
+				t.Errorf("View b exists: want %v, got %v", tc.exists, got)
+			}
+
+			if tc.exists {
+				checkViews(t, g, map[string]size{
+					"b": tc.hidden,
+				})
+			}
+
+			if err := l.HideItem("b", LayoutVisible); err != nil {
+				t.Fatalf("HideItem failed: %v", err)
+			}
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			checkViews(t, g, map[string]size{
+				"a": {0, 0, 39, 24},
+				"b": {40, 0, 79, 24},
+			})
+		})
+	}
+}
+
+func TestHiddenDeletedRestore(t *testing.T) {
+	g := newTestGui(t, false)
+	var created int
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithCreate(func(v *gocui.View) error {
+			created++
+			fmt.Fprintln(v, "one\ntwo\nthree")
+			return nil
+		})),
+	)
+	l.SetHiddenViews(HiddenDeleted)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	v, err := g.View("b")
+	if err != nil {
+		t.Fatalf("View b not found: %v", err)
+	}
+	v.Highlight = true
+	v.Title = "Bee"
+	if err := v.SetCursor(0, 1); err != nil {
+		t.Fatalf("SetCursor failed: %v", err)
+	}
+
+	if err := l.ToggleItem("b"); err != nil {
+		t.Fatalf("ToggleItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if viewExists(g, "b") {
+		t.Fatalf("View b not deleted")
+	}
+
+	if err := l.ToggleItem("b"); err != nil {
+		t.Fatalf("ToggleItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	v, err = g.View("b")
+	if err != nil {
+		t.Fatalf("View b not restored: %v", err)
+	}
+	if created != 1 {
+		t.Errorf("Expected the create function to be called once, got %d", created)
+	}
+	if got, want := v.Buffer(), "one\ntwo\nthree\n"; got != want {
+		t.Errorf("Unexpected contents: got %q, want %q", got, want)
+	}
+	if _, cy := v.Cursor(); cy != 1 || !v.Highlight || v.Title != "Bee" {
+		t.Errorf("Settings not restored: cursor %d, highlight %v, title %q", cy, v.Highlight, v.Title)
+	}
+}
@@ -0,0 +1,193 @@
+package layout
+
+import (
+	"fmt"
+)
+
+// NoHistory is an error returned when there is nothing to undo or redo.
+var NoHistory = fmt.Errorf("No changes to undo or redo")
+
+// maxHistory is the number of changes that can be undone.
+const maxHistory = 100
+
+type itemState struct {
+	item    *layoutItem
+	name    string
+	ratio   int
+	fixed   int
+	hidden  HideLayout
+	inner   *layoutLevel
+	route   string
+	float   *floatState
+	options itemOptions
+}
+
+type levelState struct {
+	level        *layoutLevel
+	direction    LayoutDirection
+	items        []*layoutItem
+	split        int
+	current      int
+	proportional bool
+}
+
+// treeState records everything about a tree that can be changed through the
+// layout's methods.
+type treeState struct {
+	items  []itemState
+	levels []levelState
+	floats []*layoutItem
+}
+
+// capture records the current state of the level and everything below it.
+func (l *layoutLevel) capture() *treeState {
+	s := &treeState{floats: append([]*layoutItem(nil), l.floats...)}
+	l.captureInto(s)
+	return s
+}
+
+func (l *layoutLevel) captureInto(s *treeState) {
+	ls := levelState{
+		level:        l,
+		direction:    l.direction,
+		items:        append([]*layoutItem(nil), l.items...),
+		proportional: l.proportional,
+	}
+	if l.splitter != nil {
+		ls.split = l.splitter.percent
+	}
+	if l.carousel != nil {
+		ls.current = l.carousel.current
+	}
+	s.levels = append(s.levels, ls)
+
+	for _, item := range l.items {
+		is := itemState{
+			item:   item,
+			name:   item.name,
+			ratio:  item.ratio,
+			fixed:  item.fixed,
+			hidden: item.hidden,
+			inner:  item.inner,
+
+			options: item.itemOptions,
+		}
+		if item.router != nil {
+			is.route = item.router.current
+		}
+		if item.float != nil {
+			f := *item.float
+			is.float = &f
+		}
+		s.items = append(s.items, is)
+
+		if item.inner != nil {
+			item.inner.captureInto(s)
+		}
+	}
+}
+
+// restore returns the tree to a recorded state. Views that are no longer part
+// of the tree are deleted on the next render.
+func (l *layoutLevel) restore(s *treeState) {
+	l.changed()
+	l.dropItems(l)
+	l.floats = append([]*layoutItem(nil), s.floats...)
+
+	for _, ls := range s.levels {
+		ls.level.direction = ls.direction
+		ls.level.items = append([]*layoutItem(nil), ls.items...)
+		ls.level.proportional = ls.proportional
+		if ls.level.splitter != nil {
+			ls.level.splitter.percent = ls.split
+		}
+		if ls.level.carousel != nil {
+			ls.level.carousel.current = ls.current
+		}
+	}
+
+	for _, is := range s.items {
+		if is.item.name != is.name {
+			is.item.rename(is.name)
+		}
+		is.item.ratio = is.ratio
+		is.item.fixed = is.fixed
+		is.item.hidden = is.hidden
+		is.item.inner = is.inner
+		is.item.itemOptions = is.options
+		is.item.float = nil
+		if is.float != nil {
+			f := *is.float
+			is.item.float = &f
+		}
+		if is.item.router != nil {
+			is.item.router.current = is.route
+		}
+	}
+}
+
+// record saves the current state of the layout before a change, so it can be
+// undone. Within a batch, only the state before the first change is saved.
+func (l *layoutLevel) record() {
+	l.changed()
+	if l.batch > 0 {
+		if l.batchRecorded {
+			return
+		}
+		l.batchRecorded = true
+	}
+
+	l.undo = append(l.undo, l.capture())
+	if len(l.undo) > maxHistory {
+		l.undo = l.undo[1:]
+	}
+	l.redo = nil
+}
+
+// Snapshot records the current state of the layout, to be restored by Reset.
+// The state of the layout when it is first rendered is recorded automatically.
+func (l *layoutLevel) Snapshot() {
+	l.snapshot = l.capture()
+}
+
+// Reset returns the layout to the state recorded by Snapshot, restoring the
+// sizes, visibility and order of the items. Items added since are removed, and
+// those removed are added back. Reset itself can be undone.
+func (l *layoutLevel) Reset() {
+	if l.snapshot == nil {
+		return
+	}
+
+	l.record()
+	l.restore(l.snapshot)
+}
+
+// Undo reverts the last change made through the layout's methods, such as
+// hiding, resizing, adding, removing or moving items. A batch of changes is
+// reverted as a whole.
+func (l *layoutLevel) Undo() error {
+	if len(l.undo) == 0 {
+		return NoHistory
+	}
+
+	s := l.undo[len(l.undo)-1]
+	l.undo = l.undo[:len(l.undo)-1]
+	l.redo = append(l.redo, l.capture())
+	l.restore(s)
+
+	return nil
+}
+
+// Redo reapplies the last change reverted by Undo.
+func (l *layoutLevel) Redo() error {
+	if len(l.redo) == 0 {
+		return NoHistory
+	}
+
+	s := l.redo[len(l.redo)-1]
+	l.redo = l.redo[:len(l.redo)-1]
+	l.undo = append(l.undo, l.capture())
+	l.restore(s)
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.Undo(); !errors.Is(err, NoHistory) {
+		t.Errorf("Undo with no history: want %v, got %v", NoHistory, err)
+	}
+
+	if err := l.AddItem("", -1, NewRatioItem(1, "c")); err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+	if err := l.HideItem("a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	err := l.Mutate(func(l *layoutLevel) error {
+		if err := l.SwapItems("b", "c"); err != nil {
+			return err
+		}
+		return l.ResizeItem("c", 0, 10)
+	})
+	if err != nil {
+		t.Fatalf("Mutate failed: %v", err)
+	}
+	if err := l.RenameItem("b", "bee"); err != nil {
+		t.Fatalf("RenameItem failed: %v", err)
+	}
+
+	tests := []struct {
+		desc  string
+		f     func() error
+		want  string
+		views map[string]size
+	}{
+		{
+			desc: "undo rename",
+			f:    l.Undo,
+			want: "H(a c b)",
+			views: map[string]size{
+				"c": {0, 0, 9, 24},
+				"b": {10, 0, 79, 24},
+			},
+		},
+		{
+			desc: "undo batch",
+			f:    l.Undo,
+			want: "H(a b c)",
+			views: map[string]size{
+				"b": {0, 0, 39, 24},
+				"c": {40, 0, 79, 24},
+			},
+		},
+		{
+			desc: "undo hide",
+			f:    l.Undo,
+			want: "H(a b c)",
+			views: map[string]size{
+				"a": {0, 0, 25, 24},
+				"b": {26, 0, 51, 24},
+				"c": {52, 0, 79, 24},
+			},
+		},
+		{
+			desc: "undo add",
+			f:    l.Undo,
+			want: "H(a b)",
+			views: map[string]size{
+				"a": {0, 0, 39, 24},
+				"b": {40, 0, 79, 24},
+			},
+		},
+		{
+			desc: "redo add",
+			f:    l.Redo,
+			want: "H(a b c)",
+			views: map[string]size{
+				"a": {0, 0, 25, 24},
+				"b": {26, 0, 51, 24},
+				"c": {52, 0, 79, 24},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tc.f(); err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			if got := describe(l); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			checkViews(t, g, tc.views)
+		})
+	}
+
+	if err := l.RemoveItem("c"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Redo(); !errors.Is(err, NoHistory) {
+		t.Errorf("Redo after a change: want %v, got %v", NoHistory, err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+			NewRatioItem(1, "d", Hidden()),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.ResizeItem("a", 0, 10); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	if err := l.ToggleItem("d"); err != nil {
+		t.Fatalf("ToggleItem failed: %v", err)
+	}
+	if err := l.SwapItems("a", "c"); err != nil {
+		t.Fatalf("SwapItems failed: %v", err)
+	}
+	if err := l.RemoveItem("d"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	l.Reset()
+	if got, want := describe(l), "H(a V(c d))"; got != want {
+		t.Errorf("Reset: want %q, got %q", want, got)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 39, 24},
+		"c": {40, 0, 79, 24},
+	})
+
+	if err := l.GrowItem("a", 10); err != nil {
+		t.Fatalf("GrowItem failed: %v", err)
+	}
+	l.Snapshot()
+	if err := l.HideItem("a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	l.Reset()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 49, 24},
+		"c": {50, 0, 79, 24},
+	})
+}
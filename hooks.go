@@ -0,0 +1,111 @@
+package layout
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// HideVetoed is an error returned when hiding an item was prevented by the
+// function set with BeforeHide.
+var HideVetoed = fmt.Errorf("Hiding the item was vetoed")
+
+// Summary is the outcome of rendering the layout, passed to the function set
+// with AfterLayout.
+type Summary struct {
+	// The size of the area the layout was rendered in.
+	Width, Height int
+	// The names of the views displayed, in order, see VisibleItems.
+	Visible []string
+	// Whether the area was too small for the layout, either displaying the
+	// placeholder set with ShowTooSmall, or failing with TooSmall.
+	TooSmall bool
+	// The error returned by the render, if any.
+	Err error
+}
+
+// BeforeLayout sets a function to call each time the layout is about to be
+// rendered, with the width and height it is rendered in. Changes made to the
+// layout from f, such as hiding items that don't fit, are part of that
+// render. A nil f removes it.
+func (l *layoutLevel) BeforeLayout(f func(w, h int)) {
+	l.beforeLayout = f
+}
+
+// AfterLayout sets a function to call each time the layout was rendered, with
+// a summary of the outcome, such as to update a status line. A nil f removes
+// it.
+func (l *layoutLevel) AfterLayout(f func(result Summary)) {
+	l.afterLayout = f
+}
+
+// summary describes the render of the layout in a w by h area, which returned
+// err.
+func (l *layoutLevel) summary(g *gocui.Gui, w, h int, err error) Summary {
+	s := Summary{
+		Width:    w,
+		Height:   h,
+		TooSmall: errors.Is(err, TooSmall),
+		Err:      err,
+	}
+	if l.tooSmall {
+		if _, verr := g.View(tooSmallView); verr == nil {
+			s.TooSmall = true
+		}
+	}
+	if !s.TooSmall {
+		s.Visible = l.VisibleItems()
+	}
+	return s
+}
+
+// BeforeHide sets a function to call with the name of an item about to be
+// hidden by HideItem or ToggleItem, which can prevent it by returning false,
+// such as while the item has unsaved content. HideItem and ToggleItem then
+// return HideVetoed, leaving the item displayed. Showing items is never
+// prevented. A nil f removes it.
+func (l *layoutLevel) BeforeHide(f func(name string) bool) {
+	l.beforeHide = f
+}
+
+// vetoHide returns HideVetoed if the function set with BeforeHide prevents
+// the item from being hidden.
+func (l *layoutLevel) vetoHide(item *layoutItem) error {
+	if l.beforeHide != nil && !bool(item.hidden) && !l.beforeHide(item.name) {
+		return l.itemError(item.name, HideVetoed)
+	}
+	return nil
+}
+
+// OnReady sets a function to call once, after the first render of the layout
+// in which the views of all the items displayed were created, such as to set
+// the initial focus or write the initial contents of views. Set after that,
+// f is called after the next such render. A nil f removes it.
+func (l *layoutLevel) OnReady(f func()) {
+	l.onReady = f
+	l.ready = false
+}
+
+// notifyReady calls the function set with OnReady if it wasn't yet, and all
+// the displayed views exist.
+func (l *layoutLevel) notifyReady(g *gocui.Gui) {
+	if l.onReady == nil || l.ready {
+		return
+	}
+	ready := true
+	l.visibleViews(func(item *layoutItem) {
+		if _, err := g.View(item.name); err != nil {
+			ready = false
+		}
+	})
+	if !ready {
+		return
+	}
+	l.ready = true
+	f := l.onReady
+	call(g, l.deferCallbacks, nil, func() error {
+		f()
+		return nil
+	})
+}
@@ -0,0 +1,148 @@
+package layout
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLayoutHooks(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewFixedItem(3, "status"),
+		NewRatioItem(1, "body", WithInner(NewLevel(LayoutHorizontal,
+			NewRatioItem(1, "main"),
+			NewFixedItem(30, "sidebar"),
+		))),
+	)
+
+	var sizes [][2]int
+	var results []Summary
+	l.BeforeLayout(func(w, h int) {
+		sizes = append(sizes, [2]int{w, h})
+		// Hide the sidebar when there isn't room for it.
+		l.HideItem("sidebar", HideLayout(w < 60))
+	})
+	l.AfterLayout(func(result Summary) {
+		results = append(results, result)
+	})
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.LayoutRect(g, 0, 0, 39, 9); err != nil {
+		t.Fatalf("LayoutRect failed: %v", err)
+	}
+	l.ShowTooSmall(true)
+	if err := l.LayoutRect(g, 0, 0, 39, 1); err != nil {
+		t.Fatalf("LayoutRect failed: %v", err)
+	}
+
+	if want := [][2]int{{80, 25}, {40, 10}, {40, 2}}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("Unexpected sizes: got %v, want %v", sizes, want)
+	}
+	want := []Summary{
+		{Width: 80, Height: 25, Visible: []string{"status", "main", "sidebar"}},
+		{Width: 40, Height: 10, Visible: []string{"status", "main"}},
+		{Width: 40, Height: 2, TooSmall: true},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Unexpected results:\ngot  %+v\nwant %+v", results, want)
+	}
+	if _, err := g.View("sidebar"); err != nil {
+		t.Errorf("Expected the hidden sidebar's view: %v", err)
+	}
+
+	// Removed hooks are no longer called.
+	l.BeforeLayout(nil)
+	l.AfterLayout(nil)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if len(sizes) != 3 || len(results) != 3 {
+		t.Errorf("Hooks called after being removed")
+	}
+}
+
+func TestBeforeHide(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "editor"),
+		NewRatioItem(1, "log"),
+	)
+	unsaved := true
+	var asked []string
+	l.BeforeHide(func(name string) bool {
+		asked = append(asked, name)
+		return name != "editor" || !unsaved
+	})
+
+	if err := l.HideItem("editor", true); !errors.Is(err, HideVetoed) {
+		t.Errorf("Expected HideVetoed, got %v", err)
+	}
+	if err := l.ToggleItem("editor"); !errors.Is(err, HideVetoed) {
+		t.Errorf("Expected HideVetoed, got %v", err)
+	}
+	if err := l.ToggleItem("log"); err != nil {
+		t.Errorf("ToggleItem failed: %v", err)
+	}
+	// Showing items isn't checked.
+	if err := l.HideItem("log", false); err != nil {
+		t.Errorf("HideItem failed: %v", err)
+	}
+	if want := []string{"editor", "editor", "log"}; !reflect.DeepEqual(asked, want) {
+		t.Errorf("Unexpected items checked: got %v, want %v", asked, want)
+	}
+	if l.items[0].hidden {
+		t.Errorf("Expected the editor to stay displayed")
+	}
+
+	unsaved = false
+	if err := l.ToggleItem("editor"); err != nil {
+		t.Errorf("ToggleItem failed: %v", err)
+	}
+	if !l.items[0].hidden {
+		t.Errorf("Expected the editor to be hidden")
+	}
+}
+
+func TestOnReady(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewFixedItem(10, "top"),
+		NewRatioItem(1, "main"),
+	)
+	calls := 0
+	l.OnReady(func() {
+		if _, err := g.View("main"); err != nil {
+			t.Errorf("Expected main's view to exist: %v", err)
+		}
+		calls++
+	})
+
+	// Too small, the views aren't all created.
+	if err := l.LayoutRect(g, 0, 0, 79, 5); !errors.Is(err, TooSmall) {
+		t.Fatalf("Expected TooSmall, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Unexpected call before the views exist")
+	}
+	for i := 0; i < 2; i++ {
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single call, got %d", calls)
+	}
+
+	// Set again, it is called after the next render.
+	l.OnReady(func() {
+		calls++
+	})
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected a second call, got %d", calls-1)
+	}
+}
@@ -0,0 +1,108 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// jumpView is the name of the view holding the focus in jump mode, and the
+// prefix of the badges' views.
+const jumpView = "_layoutJump"
+
+// jumpLabels are the keys used to jump to each view, in focus order.
+const jumpLabels = "123456789abcdefghijklmnopqrstuvwxyz"
+
+// jumpState is the views that can be jumped to, and the view that had the
+// focus before.
+type jumpState struct {
+	items []*layoutItem
+	prev  string
+}
+
+// JumpMode displays a badge with a key over each view that can be focused.
+// Pressing one of the keys focuses its view, and Esc leaves the focus where
+// it was. Either way, the badges are removed.
+func (l *layoutLevel) JumpMode(g *gocui.Gui) error {
+	if l.jumping != nil {
+		if err := l.jump(g, 0); err != nil {
+			return err
+		}
+	}
+
+	items := l.focusOrder()
+	if len(items) > len(jumpLabels) {
+		items = items[:len(jumpLabels)]
+	}
+	l.jumping = &jumpState{items: items}
+	if v := g.CurrentView(); v != nil {
+		l.jumping.prev = v.Name()
+	}
+
+	if _, err := g.SetView(jumpView, -3, -3, -1, -1, 0); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	for idx, item := range items {
+		label := rune(jumpLabels[idx])
+		r := item.rect
+		cx, cy := (r.X0+r.X1)/2, (r.Y0+r.Y1)/2
+		v, err := g.SetView(jumpBadge(idx), cx-2, cy-1, cx+2, cy+1, 0)
+		if err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Clear()
+		fmt.Fprintf(v, " %c ", label)
+		if _, err := g.SetViewOnTop(jumpBadge(idx)); err != nil {
+			return err
+		}
+
+		if err := g.SetKeybinding(jumpView, label, gocui.ModNone, func(g *gocui.Gui, _ *gocui.View) error {
+			return l.jump(g, label)
+		}); err != nil {
+			return err
+		}
+	}
+	if err := g.SetKeybinding(jumpView, gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, _ *gocui.View) error {
+		return l.jump(g, 0)
+	}); err != nil {
+		return err
+	}
+
+	_, err := g.SetCurrentView(jumpView)
+	return err
+}
+
+// jump leaves jump mode, focusing the view of the given label, or the view
+// that had the focus before for any other.
+func (l *layoutLevel) jump(g *gocui.Gui, label rune) error {
+	j := l.jumping
+	l.jumping = nil
+	if j == nil {
+		return nil
+	}
+
+	g.DeleteKeybindings(jumpView)
+	if err := g.DeleteView(jumpView); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	for idx := range j.items {
+		if err := g.DeleteView(jumpBadge(idx)); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+
+	if idx := strings.IndexRune(jumpLabels, label); label != 0 && idx >= 0 && idx < len(j.items) {
+		return l.focus(g, j.items[idx])
+	}
+	if j.prev != "" && j.prev != jumpView {
+		if _, err := g.SetCurrentView(j.prev); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	return nil
+}
+
+func jumpBadge(idx int) string {
+	return fmt.Sprintf("%s%c", jumpView, jumpLabels[idx])
+}
@@ -0,0 +1,54 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestJumpMode(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "c", NotFocusable()),
+		NewRatioItem(1, "d"),
+	)
+	if err := l.Focus("a"); err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.JumpMode(g); err != nil {
+		t.Fatalf("JumpMode failed: %v", err)
+	}
+	for label, item := range map[string]string{"1": "a", "2": "b", "3": "d"} {
+		v, err := g.View(jumpView + label)
+		if err != nil {
+			t.Fatalf("Badge %s not found: %v", label, err)
+		}
+		if got, want := v.Buffer(), " "+label+" "; got != want {
+			t.Errorf("Unexpected badge for %s: want %q, got %q", item, want, got)
+		}
+	}
+	if err := l.jump(g, '3'); err != nil {
+		t.Fatalf("jump failed: %v", err)
+	}
+	if v := g.CurrentView(); v == nil || v.Name() != "d" {
+		t.Errorf("Expected d to be focused, got %v", v)
+	}
+	if viewExists(g, jumpView) || viewExists(g, jumpView+"1") {
+		t.Errorf("Expected the badges to be removed")
+	}
+
+	// Any other key returns the focus.
+	if err := l.JumpMode(g); err != nil {
+		t.Fatalf("JumpMode failed: %v", err)
+	}
+	if err := l.jump(g, 0); err != nil {
+		t.Fatalf("jump failed: %v", err)
+	}
+	if v := g.CurrentView(); v == nil || v.Name() != "d" {
+		t.Errorf("Expected d to keep the focus, got %v", v)
+	}
+}
@@ -0,0 +1,87 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+type itemKey struct {
+	key     interface{}
+	mod     gocui.Modifier
+	handler func(*gocui.Gui, *gocui.View) error
+}
+
+// boundKeys are the keys of an item bound on its view.
+type boundKeys struct {
+	item *layoutItem
+	keys []itemKey
+}
+
+// WithKeybinding binds key, with mod, to handler on the item's view, as
+// gocui's SetKeybinding does, but only while the item is displayed. The
+// binding is set when the layout is rendered with the item displayed, and
+// deleted once it is rendered with the item hidden or removed, so that the
+// keys of hidden views are not taken while they still have the focus. The
+// option can be given several times to bind several keys, including with
+// SetItemOptions, which adds to the item's keys rather than replacing them.
+func WithKeybinding(key interface{}, mod gocui.Modifier, handler func(*gocui.Gui, *gocui.View) error) layoutItemOption {
+	return func(l *layoutItem) {
+		l.keys = append(l.keys, itemKey{key, mod, handler})
+	}
+}
+
+// bindItemKeys sets the keybindings of the displayed items, and deletes those
+// of items no longer displayed.
+func (l *layoutLevel) bindItemKeys(g *gocui.Gui, version uint64) error {
+	if l.keysBound != nil && version == l.keysVersion {
+		return nil
+	}
+	l.keysVersion = version
+
+	// While a modal is shown, only its own items have their keys.
+	items := make(map[string]*layoutItem)
+	if len(l.modals) == 0 {
+		l.viewItems(func(item *layoutItem) {
+			if (len(item.keys) > 0 || item.menu != nil) && item.displayed {
+				items[item.name] = item
+			}
+		})
+	}
+
+	if l.keysBound == nil {
+		l.keysBound = make(map[string]boundKeys)
+	}
+	for name, bound := range l.keysBound {
+		if item := items[name]; item == bound.item && len(l.itemKeys(item)) == len(bound.keys) {
+			continue
+		}
+		for _, k := range bound.keys {
+			g.DeleteKeybinding(name, k.key, k.mod)
+		}
+		delete(l.keysBound, name)
+	}
+	for name, item := range items {
+		if _, ok := l.keysBound[name]; ok {
+			continue
+		}
+		keys := l.itemKeys(item)
+		for _, k := range keys {
+			if err := g.SetKeybinding(name, k.key, k.mod, k.handler); err != nil {
+				return err
+			}
+		}
+		l.keysBound[name] = boundKeys{item, keys}
+	}
+
+	return nil
+}
+
+// unbindItemKeys deletes the keybindings of all the items, such as when the
+// layout is no longer displayed. They are set again once it is rendered.
+func (l *layoutLevel) unbindItemKeys(g *gocui.Gui) {
+	for name, bound := range l.keysBound {
+		for _, k := range bound.keys {
+			g.DeleteKeybinding(name, k.key, k.mod)
+		}
+	}
+	l.keysBound = nil
+}
@@ -0,0 +1,64 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestWithKeybinding(t *testing.T) {
+	g := newTestGui(t, false)
+	handler := func(*gocui.Gui, *gocui.View) error { return nil }
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a",
+			WithKeybinding('x', gocui.ModNone, handler),
+			WithKeybinding(gocui.KeyF1, gocui.ModNone, handler),
+		),
+		NewRatioItem(1, "b", WithKeybinding('y', gocui.ModAlt, handler)),
+		NewRatioItem(1, "c", Hidden(), WithKeybinding('z', gocui.ModNone, handler)),
+	)
+
+	bound := func(want ...string) {
+		t.Helper()
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+		if len(l.keysBound) != len(want) {
+			t.Errorf("Unexpected bindings: got %v, want %v", l.keysBound, want)
+		}
+		for _, name := range want {
+			if _, ok := l.keysBound[name]; !ok {
+				t.Errorf("Keys of %q not bound", name)
+			}
+		}
+	}
+
+	bound("a", "b")
+
+	if err := l.HideItem("a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.HideItem("c", LayoutVisible); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	bound("b", "c")
+	if err := g.DeleteKeybinding("a", 'x', gocui.ModNone); err == nil {
+		t.Errorf("Keybinding of hidden item not deleted")
+	}
+
+	if err := l.SetItemOptions("c", WithKeybinding('w', gocui.ModNone, handler)); err != nil {
+		t.Fatalf("SetItemOptions failed: %v", err)
+	}
+	bound("b", "c")
+	if n := len(l.keysBound["c"].keys); n != 2 {
+		t.Errorf("Unexpected number of keys bound for c: got %d, want 2", n)
+	}
+
+	if err := l.RemoveItem("b"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	bound("c")
+	if err := g.DeleteKeybinding("c", 'z', gocui.ModNone); err != nil {
+		t.Errorf("Keybinding of displayed item not set: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package layout
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/awesome-gocui/gocui"
 )
@@ -17,6 +18,18 @@ type HideLayout bool
 // exist.
 var NotFound = fmt.Errorf("Item not found")
 
+// DuplicateName is an error returned when adding something under a name that
+// is already in use.
+var DuplicateName = fmt.Errorf("Name already in use")
+
+// NotContainer is an error returned when an item without an inner level is
+// used where one is needed.
+var NotContainer = fmt.Errorf("Item does not contain a level")
+
+// TooSmall is an error returned when there isn't enough space to render the
+// layout.
+var TooSmall = fmt.Errorf("Window too small")
+
 // InvalidValues is an error returned when both fixes and ratios are specified
 // for the same item.
 var InvalidValues = fmt.Errorf("Fixes and Ratio parameters are not compatible")
@@ -30,13 +43,92 @@ const (
 )
 
 type layoutItem struct {
-	ratio   int
-	fixed   int
-	name    string
-	hidden  HideLayout
-	inner   *layoutLevel
-	fNew    func(*gocui.View) error
-	fUpdate func(*gocui.View) error
+	ratio  int
+	fixed  int
+	name   string
+	hidden HideLayout
+	inner  *layoutLevel
+	rect   Rect
+	size   int
+	router *routerState
+
+	// Whether the item was displayed, in rect, when last rendered.
+	displayed bool
+
+	// Set by the options the item was created with, or SetItemOptions.
+	itemOptions
+
+	// The name of the item's view before RenameItem was called.
+	renamedFrom string
+
+	// When fUpdate was last called, see WithUpdateOnResize and
+	// WithUpdateEvery.
+	updatedRect Rect
+	updatedAt   time.Time
+
+	// Where the view was last displayed, see WithOnResize.
+	resizedRect Rect
+
+	// The move of the item's view, see AnimateResize, and where it was last
+	// placed.
+	anim     animation
+	viewRect Rect
+
+	// The error returned by the last call of the create or update function,
+	// kept rather than returned, see SetItemErrors.
+	err error
+
+	// The view deleted while the item was hidden, see HiddenDeleted.
+	saved *gocui.View
+
+	// The sizes the item had before it was collapsed, see ToggleCollapse.
+	collapsed *[2]int
+
+	// Set while the item is displayed over the layout, see FloatItem.
+	float *floatState
+}
+
+// itemOptions are the settings of an item other than its size, visibility
+// and level, which are set by options such as WithTitle, and are recorded as
+// a whole for Undo.
+type itemOptions struct {
+	manager gocui.Manager
+	data    interface{}
+	tags    []string
+
+	// The title of the view, if set with WithTitle.
+	title    string
+	hasTitle bool
+
+	fNew       func(*gocui.View) error
+	fUpdate    func(*gocui.View) error
+	fUpdateCtx func(*gocui.Gui, *gocui.View, ItemInfo) error
+	fDestroy   func(*gocui.View) error
+
+	// When to call fUpdate, see WithUpdateOnResize and WithUpdateEvery.
+	updateOnResize bool
+	updateEvery    time.Duration
+
+	// Called when the view is moved or resized, see WithOnResize.
+	fResize func(v *gocui.View, old, new Rect) error
+
+	// The item's place in the focus order, see WithTabIndex.
+	tabIndex     int
+	hasTabIndex  bool
+	notFocusable bool
+	initialFocus bool
+
+	// Called when the item gains or loses the focus, see WithOnFocus.
+	fFocus func(*gocui.View) error
+	fBlur  func(*gocui.View) error
+
+	// Bound while the item is displayed, see WithKeybinding, and the
+	// entries of its context menu, see WithContextMenu.
+	keys []itemKey
+	menu []MenuEntry
+
+	// Drawn on the view's border, see WithButtons.
+	buttons []Button
 }
 
 type layoutItemOption func(l *layoutItem)
@@ -71,6 +163,64 @@ func WithUpdate(f func(*gocui.View) error) layoutItemOption {
 	}
 }
 
+// WithUpdateCtx passes a function that is called each time the layout is
+// rendered, as with WithUpdate, and also once the view is created, with the
+// gui and a description of the item: its rectangle, whether the view was just
+// created, whether it is displayed and whether it has the focus.
+// WithUpdateOnResize and WithUpdateEvery limit it the same way.
+func WithUpdateCtx(f func(g *gocui.Gui, v *gocui.View, info ItemInfo) error) layoutItemOption {
+	return func(l *layoutItem) {
+		l.fUpdateCtx = f
+	}
+}
+
+// WithUpdateOnResize limits the function passed to WithUpdate to being called
+// only when the size or position of the view changed, rather than every time
+// the layout is rendered.
+func WithUpdateOnResize() layoutItemOption {
+	return func(l *layoutItem) {
+		l.updateOnResize = true
+	}
+}
+
+// WithUpdateEvery limits the function passed to WithUpdate to being called at
+// most once per interval. It is only called when the layout is rendered, so to
+// update the view on a timer, the layout needs to be rendered as well, such as
+// through the gui's Update. Combined with WithUpdateOnResize, the function is
+// also called whenever the view is resized.
+func WithUpdateEvery(interval time.Duration) layoutItemOption {
+	return func(l *layoutItem) {
+		l.updateEvery = interval
+	}
+}
+
+// WithOnResize calls f with the item's view, and the rectangles it was last
+// displayed in and is now, when the layout is rendered with the view moved or
+// resized. It isn't called when the view is first created, nor when the item
+// is hidden, but is once the item is displayed again elsewhere. It is called
+// after the function passed to WithUpdate.
+func WithOnResize(f func(v *gocui.View, old, new Rect) error) layoutItemOption {
+	return func(l *layoutItem) {
+		l.fResize = f
+	}
+}
+
+// WithTitle sets the title of the item's view. An empty title clears it.
+func WithTitle(title string) layoutItemOption {
+	return func(l *layoutItem) {
+		l.title = title
+		l.hasTitle = true
+	}
+}
+
+// WithUserData attaches arbitrary data to the item, such as the model it
+// displays, to be retrieved with UserData.
+func WithUserData(data interface{}) layoutItemOption {
+	return func(l *layoutItem) {
+		l.data = data
+	}
+}
+
 // NewRatioItem creates a new item, that is to take a given ratio of the total
 // available space.
 func NewRatioItem(weight int, name string, opts ...layoutItemOption) *layoutItem {
@@ -82,6 +232,24 @@ func NewFixedItem(size int, name string, opts ...layoutItemOption) *layoutItem {
 	return createNewItem(-size, name, opts...)
 }
 
+// NewRatioItemE is like NewRatioItem, but returns InvalidValues rather than
+// panicking when the weight isn't positive, such as when read from a config.
+func NewRatioItemE(weight int, name string, opts ...layoutItemOption) (*layoutItem, error) {
+	if weight <= 0 {
+		return nil, &ItemError{Item: name, Err: fmt.Errorf("%w: weight %d", InvalidValues, weight)}
+	}
+	return createNewItem(weight, name, opts...), nil
+}
+
+// NewFixedItemE is like NewFixedItem, but returns InvalidValues rather than
+// panicking when the size isn't positive.
+func NewFixedItemE(size int, name string, opts ...layoutItemOption) (*layoutItem, error) {
+	if size <= 0 {
+		return nil, &ItemError{Item: name, Err: fmt.Errorf("%w: size %d", InvalidValues, size)}
+	}
+	return createNewItem(-size, name, opts...), nil
+}
+
 func createNewItem(size int, name string, opts ...layoutItemOption) *layoutItem {
 	var ratio, fixed int
 	if size > 0 {
@@ -105,8 +273,28 @@ func createNewItem(size int, name string, opts ...layoutItemOption) *layoutItem
 	return i
 }
 
+// viewNames returns the names of the views created for the item.
+func (l *layoutItem) viewNames() []string {
+	return l.appendViewNames(nil)
+}
+
+func (l *layoutItem) appendViewNames(names []string) []string {
+	if l.inner != nil {
+		return l.inner.appendViewNames(names)
+	}
+	if l.manager != nil {
+		return names
+	}
+	if l.float != nil {
+		names = l.appendHandleNames(names)
+	}
+	return append(names, l.name)
+}
+
+// isHidden reports if the item takes no space within its level, because it
+// was hidden, floats, or only holds hidden items.
 func (l *layoutItem) isHidden() HideLayout {
-	if l.hidden {
+	if l.hidden || l.float != nil {
 		return LayoutHidden
 	}
 	if l.inner != nil {
@@ -117,19 +305,236 @@ func (l *layoutItem) isHidden() HideLayout {
 }
 
 type layoutLevel struct {
+	name      string
 	direction LayoutDirection
 	items     []*layoutItem
+
+	// The area the level was last laid out in.
+	rect Rect
+
+	// Set for levels created with NewSplitter.
+	splitter *splitState
+
+	// The views over the borders of the level, by name, with the item before
+	// each, see EnableDragBorders.
+	sashes map[string]*layoutItem
+
+	// The views over the top borders of the level's items, by name, see
+	// EnableDragItems.
+	grips map[string]*layoutItem
+
+	// Set for levels created with NewCarousel.
+	carousel *carouselState
+
+	// Displayed instead of the level's own items, see Takeover.
+	takeover *layoutLevel
+
+	// Displayed over the level, the last one having the focus, see ShowModal.
+	modals []*modalState
+
+	// The items displayed over the layout rather than within it, the last
+	// one on top, see FloatItem.
+	floats []*layoutItem
+
+	// Recorded states, see Undo and Redo.
+	undo []*treeState
+	redo []*treeState
+
+	// The state to return to, see Snapshot and Reset.
+	snapshot *treeState
+
+	// Views to delete on the next layout, unless they are back in the tree by
+	// then.
+	stale []string
+
+	// The destroy functions of the items whose views are to be deleted, by
+	// view name, see WithDestroy.
+	destroys map[string]func(*gocui.View) error
+
+	// How the space is divided between ratio items, see SetRounding.
+	rounding  Rounding
+	remainder Remainder
+
+	// Whether the weights of the ratio items are proportions, such as those
+	// set by GrowItem, shared out exactly whatever the space, rather than
+	// units of space, see shareOut.
+	proportional bool
+
+	// The item to focus once rendered, see Focus, and whether the item
+	// marked with WithInitialFocus was.
+	focusName        string
+	focusedInitially bool
+
+	// The displayed items with buttons, as of the tree version last
+	// rendered, and the views holding them, see WithButtons.
+	buttonItems []*layoutItem
+	buttonViews map[string]bool
+
+	// The geometry computed since the last change to the level.
+	cache        map[cacheKey][]placement
+	cacheVersion uint64
+
+	// The item displayed over the whole layout, see Zoom.
+	zoomed *layoutItem
+
+	// Kept when the tree is replaced, see Replace.
+	layoutSettings
+}
+
+// layoutSettings are the settings and handlers set on a level, and what it
+// knows about the gui's views, such as those it created, styled or bound keys
+// for. They belong to the level as the gui's manager rather than to its tree,
+// so they are kept by Replace.
+type layoutSettings struct {
+	// Whether the borders between items can be dragged, see
+	// EnableDragBorders.
+	dragBorders bool
+
+	// What double-clicking a border resets, see SetDoubleClickEqualize.
+	equalizeScope EqualizeScope
+
+	// Whether items can be moved with the mouse, see EnableDragItems.
+	dragItems bool
+
+	// The number of open batches, see Begin, and whether the state before the
+	// batch was recorded.
+	batch         int
+	batchRecorded bool
+
+	// Views created while rendering the layout, see Prune, as of the tree
+	// version last tracked.
+	created map[string]bool
+	tracked uint64
+
+	// The last number given to a level added by commands such as
+	// SplitFocused, see newContainer.
+	containers int
+
+	// Whether to display a placeholder when the screen is too small, see
+	// ShowTooSmall.
+	tooSmall bool
+
+	// Whether to render the rest of the layout after an item fails, see
+	// ContinueOnError.
+	continueOnError bool
+
+	// Called before and after each render, see BeforeLayout and
+	// AfterLayout.
+	beforeLayout func(w, h int)
+	afterLayout  func(Summary)
+
+	// Called after each render, see OnStats, with what the last one did.
+	onStats func(Stats)
+	stats   Stats
+
+	// Called once all the displayed views exist, see OnReady, and whether it
+	// was.
+	onReady func()
+	ready   bool
+
+	// Can prevent items from being hidden, see BeforeHide.
+	beforeHide func(string) bool
+
+	// How long views take to move, see AnimateResize, time.Now, replaced in
+	// tests, and whether the next frame was scheduled.
+	animate      time.Duration
+	easing       Easing
+	now          func() time.Time
+	framePending bool
+
+	// Whether items shown or hidden slide in and out, see SlideToggled.
+	slide bool
+
+	// Hiding items shown for a while, see ShowFor.
+	showTimers map[string]*time.Timer
+
+	// Called when the views displayed change, see OnVisibilityChange, and
+	// those displayed as of the tree version last rendered.
+	fVisibility    func(VisibilityChange)
+	visible        []string
+	visibleVersion uint64
+
+	// Whether callbacks are called through the gui's Update, see
+	// DeferCallbacks.
+	deferCallbacks bool
+
+	// What is done when an item's create or update function fails, see
+	// SetItemErrors.
+	itemErrors ItemErrors
+
+	// Called with the errors from rendering items, see SetErrorHandler.
+	errorHandler func(item string, err error)
+
+	// How the views of hidden items are created, see SetHiddenViews.
+	hiddenViews HiddenViews
+
+	// What is displayed when all the items are hidden, see SetAllHidden,
+	// whether it was set, and whether the layout placed the view covering it.
+	allHiddenPolicy AllHiddenPolicy
+	allHiddenSet    bool
+	allHiddenShown  bool
+
+	// How the focused view is set apart, see SetFocusStyle.
+	focusStyle  *FocusStyle
+	focusStyled focusStyled
+
+	// The views being flashed, see Flash, and how.
+	flashes    map[string]*flashState
+	flashColor gocui.Attribute
+
+	// The views bound to be focused when clicked, as of the tree version
+	// last bound, see EnableMouseFocus.
+	clickFocus        bool
+	clickBound        map[string]bool
+	clickFocusVersion uint64
+
+	// The keys bound for all views through the level, see ShowHelp.
+	help []helpEntry
+
+	// The items whose keys are bound, by view name, as of the tree version
+	// last bound, see WithKeybinding.
+	keysBound   map[string]boundKeys
+	keysVersion uint64
+
+	// The tree version the buttons were last rendered for, see WithButtons.
+	buttonsVersion uint64
+
+	// The last change to the level.
+	version uint64
+
+	// Reused by layout between renders.
+	frames []frame
+
+	// The views that can be jumped to, while in JumpMode.
+	jumping *jumpState
 }
 
 // NewLevel create a new set of items to be spread either horizontally or
 // vertically.
 func NewLevel(direction LayoutDirection, items ...*layoutItem) *layoutLevel {
-	return &layoutLevel{direction, items}
+	return &layoutLevel{direction: direction, items: items}
+}
+
+// NewNamedLevel creates a new level, as NewLevel does, with a name. Methods
+// that take an item's name, such as HideItem, ResizeItem or Equalize, accept
+// the level's name in place of that of the item holding it.
+func NewNamedLevel(name string, direction LayoutDirection, items ...*layoutItem) *layoutLevel {
+	l := NewLevel(direction, items...)
+	l.name = name
+	return l
+}
+
+// is reports if the item has the given name, or holds a level with that name.
+func (l *layoutItem) is(name string) bool {
+	if l.name == name {
+		return true
+	}
+	return l.inner != nil && l.inner.name != "" && l.inner.name == name
 }
 
 func (l *layoutLevel) findItem(name string) (*layoutItem, error) {
 	for _, item := range l.items {
-		if item.name == name {
+		if item.is(name) {
 			return item, nil
 		}
 		if item.inner != nil {
@@ -137,192 +542,1239 @@ func (l *layoutLevel) findItem(name string) (*layoutItem, error) {
 			if err == nil {
 				return found, err
 			}
-			if err != NotFound {
-				return nil, err
+		}
+	}
+	return nil, &ItemError{Item: name, Err: NotFound}
+}
+
+// findPath returns the levels leading from l to the level containing the named
+// item, and the index of the item followed at each of them.
+func (l *layoutLevel) findPath(name string) ([]*layoutLevel, []int, error) {
+	for i, item := range l.items {
+		if item.is(name) {
+			return []*layoutLevel{l}, []int{i}, nil
+		}
+		if item.inner != nil {
+			levels, idx, err := item.inner.findPath(name)
+			if err == nil {
+				return append([]*layoutLevel{l}, levels...), append([]int{i}, idx...), nil
 			}
 		}
 	}
-	return nil, NotFound
+	return nil, nil, &ItemError{Item: name, Err: NotFound}
 }
 
-// ToggleItem finds the item with the specified name within the layout (or
-// sublayouts), and toggles its visibility.
-func (l *layoutLevel) ToggleItem(name string) error {
-	i, err := l.findItem(name)
-	if err != nil {
-		return err
+// insertAt adds an item to the level at the given index.
+func (l *layoutLevel) insertAt(idx int, item *layoutItem) {
+	l.items = append(l.items, nil)
+	copy(l.items[idx+1:], l.items[idx:])
+	l.items[idx] = item
+
+	if l.carousel != nil && idx <= l.carousel.current && len(l.items) > 1 {
+		l.carousel.current++
 	}
+}
 
-	i.hidden = !i.hidden
+// removeAt removes the item at the given index from the level.
+func (l *layoutLevel) removeAt(idx int) {
+	l.items = append(l.items[:idx], l.items[idx+1:]...)
 
-	return nil
+	if l.carousel != nil && idx < l.carousel.current {
+		l.carousel.current--
+	}
 }
 
-// ToggleItem finds the item with the specified name within the layout (or
-// sublayouts), and sets its visibility to the provided value.
-func (l *layoutLevel) HideItem(name string, hidden HideLayout) error {
-	i, err := l.findItem(name)
+// detach removes the named item from the tree. Levels left empty are removed
+// from their own parents, up to l.
+func (l *layoutLevel) detach(name string) (*layoutItem, error) {
+	levels, idx, err := l.findPath(name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	i.hidden = hidden
+	k := len(levels) - 1
+	item := levels[k].items[idx[k]]
+	levels[k].removeAt(idx[k])
+	for k > 0 && len(levels[k].items) == 0 {
+		k--
+		levels[k].removeAt(idx[k])
+	}
 
-	return nil
+	return item, nil
 }
 
-// ResizeItem changes the space allocated for the named item. Either fixed or
-// ratio values can be provided, but not both.
-func (l *layoutLevel) ResizeItem(name string, ratio, fixed int) error {
+// levelOf returns the level held by the named item, or l itself if the name is
+// empty or the level's own.
+func (l *layoutLevel) levelOf(name string) (*layoutLevel, error) {
+	if name == "" || name == l.name {
+		return l, nil
+	}
+
 	i, err := l.findItem(name)
 	if err != nil {
+		return nil, err
+	}
+	if i.inner == nil {
+		return nil, l.itemError(name, NotContainer)
+	}
+
+	return i.inner, nil
+}
+
+// AddItem inserts item at the given index of the level held by the named item,
+// or of the layout itself if parentName is empty. A negative index appends the
+// item at the end of the level.
+func (l *layoutLevel) AddItem(parentName string, index int, item *layoutItem) error {
+	if err := l.checkNew(item); err != nil {
 		return err
 	}
 
-	if ratio != 0 && fixed != 0 {
-		return InvalidValues
+	level, err := l.levelOf(parentName)
+	if err != nil {
+		return err
 	}
 
-	i.ratio = ratio
-	i.fixed = fixed
+	if index > len(level.items) {
+		return &ItemError{Item: item.name, Err: InvalidValues}
+	}
+	if index < 0 {
+		index = len(level.items)
+	}
+	l.record()
+	level.insertAt(index, item)
 
 	return nil
 }
 
-func (l *layoutLevel) allHidden() HideLayout {
-	for _, item := range l.items {
-		if !item.isHidden() {
-			return LayoutVisible
-		}
+// checkNew returns DuplicateName if the item, about to be added, has the name
+// of an item already in the layout.
+func (l *layoutLevel) checkNew(item *layoutItem) error {
+	if _, err := l.findItem(item.name); err == nil {
+		return &ItemError{Item: item.name, Err: DuplicateName}
 	}
-	return LayoutHidden
+	return nil
 }
 
-func (l *layoutLevel) layout(g *gocui.Gui, x0, y0, x1, y1 int, forceHidden HideLayout) error {
-	var length, acc int
-	var overlap int
-	if !g.SupportOverlaps {
-		overlap = 1
+// MergeLevel appends the items of another level to the level held by the named
+// item, or to the layout itself if targetName is empty. None of the merged
+// items, or the items within them, may share a name with an existing item.
+func (l *layoutLevel) MergeLevel(targetName string, other *layoutLevel) error {
+	if other == nil {
+		return l.itemError(targetName, InvalidValues)
 	}
 
-	// Figure out which dimention we care about
-	if l.direction == LayoutHorizontal {
-		length = x1 - x0 + 1
-		acc = x0
-	} else {
-		length = y1 - y0 + 1
-		acc = y0
+	level, err := l.levelOf(targetName)
+	if err != nil {
+		return err
 	}
 
-	// Add up all the (visible) fixed sizes, as they're not available for assignment
-	fixed := 0
-	segments := 0
-	lastVisible := 0
-	for i, item := range l.items {
-		if forceHidden || item.isHidden() {
-			continue
-		}
-		if item.fixed > 0 {
-			fixed += item.fixed
-		} else {
-			segments += item.ratio
+	seen := make(map[string]bool)
+	for _, name := range other.itemNames() {
+		if _, err := l.findItem(name); err == nil || seen[name] {
+			return &ItemError{Item: name, Err: DuplicateName}
 		}
-		lastVisible = i
-	}
-	if length < fixed {
-		return fmt.Errorf("window too small for fixed sizes: %d < %d", length, fixed)
+		seen[name] = true
 	}
-	length -= fixed
 
-	// The rest of the space gets split between the segments
-	unit := -1
-	left := -1
-	if segments > 0 {
-		unit = length / segments
-		left = length % segments
+	l.record()
+	for _, item := range other.items {
+		level.insertAt(len(level.items), item)
 	}
 
-	if unit == 0 {
-		return fmt.Errorf("window too small for allocated units: length=%d, segments=%d", length, segments)
-	}
+	return nil
+}
 
-	for idx, item := range l.items {
-		// Make sure we still create all the views, even if they're not visible
-		var err error
-		if forceHidden || item.isHidden() {
-			if item.inner != nil {
-				err = item.inner.layout(g, x0, y0, x1, y1, LayoutHidden)
-			} else {
-				err = createView(g, item.name, x0, y0, x1, y1, 0, item.fNew, item.fUpdate)
-				g.SetViewOnBottom(item.name)
-			}
-			if err != nil {
-				return fmt.Errorf("error creating layout: %v", err)
+// itemNames returns the names of all the items in the level and its sublevels.
+func (l *layoutLevel) itemNames() []string {
+	var names []string
+	for _, item := range l.items {
+		names = append(names, item.name)
+		if item.inner != nil {
+			if item.inner.name != "" {
+				names = append(names, item.inner.name)
 			}
-			continue
+			names = append(names, item.inner.itemNames()...)
 		}
+	}
+	return names
+}
 
-		var assignment int
-		if item.fixed == 0 {
-			assignment = unit * item.ratio
-		} else {
-			assignment = item.fixed
-		}
+// RemoveItem finds the item with the specified name within the layout (or
+// sublayouts), and removes it. Its views are deleted on the next render, and
+// its space is given to the remaining items. Levels left empty are removed as
+// well.
+func (l *layoutLevel) RemoveItem(name string) error {
+	if _, err := l.findItem(name); err != nil {
+		return err
+	}
 
-		// The last item gets the leftovers
-		if idx == lastVisible {
-			assignment += left
-		}
+	l.record()
+	item, err := l.detach(name)
+	if err != nil {
+		return err
+	}
 
-		ix0, ix1, iy0, iy1 := x0, x1, y0, y1
-		if l.direction == LayoutHorizontal {
-			ix0 = acc
-			ix1 = acc + assignment - overlap
-			if ix1 > x1 {
-				ix1 = x1
-			}
-		} else {
-			iy0 = acc
-			iy1 = acc + assignment - overlap
-			if iy1 > y1 {
-				iy1 = y1
-			}
-		}
-		acc += assignment
+	l.dropItems(item)
 
-		if item.inner != nil {
-			err = item.inner.layout(g, ix0, iy0, ix1, iy1, LayoutVisible)
-		} else {
-			err = createView(g, item.name, ix0, iy0, ix1, iy1, 0, item.fNew, item.fUpdate)
-		}
+	return nil
+}
 
-		if err != nil {
-			return fmt.Errorf("error creating layout: %v", err)
-		}
+// SetInner replaces the level held by the named item. Views belonging only to
+// the previous contents of the item are deleted on the next render.
+func (l *layoutLevel) SetInner(name string, inner *layoutLevel) error {
+	i, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	if inner == nil {
+		return l.itemError(name, InvalidValues)
 	}
 
+	l.record()
+	l.dropItems(i)
+	i.inner = inner
+
 	return nil
 }
 
-func (l *layoutLevel) Layout(g *gocui.Gui) error {
-	maxX, maxY := g.Size()
-	return l.layout(g, 0, 0, maxX-1, maxY-1, LayoutVisible)
+// SetItemOptions applies options to an existing item, adding to or replacing
+// those it was created with. Views no longer used by the item are deleted on
+// the next render. Undo restores the options the item had before.
+func (l *layoutLevel) SetItemOptions(name string, opts ...layoutItemOption) error {
+	i, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	l.record()
+	l.dropItems(i)
+	for _, o := range opts {
+		o(i)
+	}
+
+	return nil
 }
 
-func createView(g *gocui.Gui, name string, x0, y0, x1, y1 int, overlaps byte,
-	fNew func(*gocui.View) error,
-	fUpdate func(*gocui.View) error) error {
-	if v, err := g.SetView(name, x0, y0, x1, y1, overlaps); err != nil {
-		if err != gocui.ErrUnknownView {
+// SetCreateFunc replaces the function called when the named item's view is
+// created. If the view already exists, it is only called if the view is
+// recreated, such as after a rename. Like SetItemOptions, it can be undone.
+func (l *layoutLevel) SetCreateFunc(name string, f func(*gocui.View) error) error {
+	i, err := l.viewItem(name)
+	if err != nil {
+		return err
+	}
+
+	l.record()
+	i.fNew = f
+
+	return nil
+}
+
+// SetUpdateFunc replaces the function called each time the named item's view
+// is rendered. Like SetItemOptions, it can be undone.
+func (l *layoutLevel) SetUpdateFunc(name string, f func(*gocui.View) error) error {
+	i, err := l.viewItem(name)
+	if err != nil {
+		return err
+	}
+
+	l.record()
+	i.fUpdate = f
+
+	return nil
+}
+
+// UserData returns the data attached to the named item with WithUserData, and
+// whether there was any.
+func (l *layoutLevel) UserData(name string) (interface{}, bool) {
+	i, err := l.findItem(name)
+	if err != nil || i.data == nil {
+		return nil, false
+	}
+
+	return i.data, true
+}
+
+// viewItem finds the named item, which must be rendered as a view rather than
+// contain a level or another manager.
+func (l *layoutLevel) viewItem(name string) (*layoutItem, error) {
+	i, err := l.findItem(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.inner != nil || i.manager != nil {
+		return nil, l.itemError(name, InvalidValues)
+	}
+
+	return i, nil
+}
+
+// ReorderItems changes the order of the items in the level held by the named
+// item, or of the layout itself if levelName is empty. The listed items are
+// placed first, in the given order, followed by any others in their existing
+// order.
+func (l *layoutLevel) ReorderItems(levelName string, names []string) error {
+	level, err := l.levelOf(levelName)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]*layoutItem)
+	for _, item := range level.items {
+		byName[item.name] = item
+	}
+
+	items := make([]*layoutItem, 0, len(level.items))
+	used := make(map[*layoutItem]bool)
+	for _, name := range names {
+		item, ok := byName[name]
+		if !ok {
+			return l.itemError(name, NotFound)
+		}
+		if used[item] {
+			return l.itemError(name, InvalidValues)
+		}
+		used[item] = true
+		items = append(items, item)
+	}
+	for _, item := range level.items {
+		if !used[item] {
+			items = append(items, item)
+		}
+	}
+
+	l.record()
+	if level.carousel != nil && len(level.items) > 0 {
+		current := level.items[level.carousel.current]
+		for i, item := range items {
+			if item == current {
+				level.carousel.current = i
+			}
+		}
+	}
+	level.items = items
+
+	return nil
+}
+
+// SwapItems exchanges the positions of the two named items, which may be in
+// different levels. Each item takes over the size of the other, so the
+// geometry of the layout stays the same.
+func (l *layoutLevel) SwapItems(a, b string) error {
+	levelsA, idxA, err := l.findPath(a)
+	if err != nil {
+		return err
+	}
+	levelsB, idxB, err := l.findPath(b)
+	if err != nil {
+		return err
+	}
+
+	lastA, lastB := len(levelsA)-1, len(levelsB)-1
+	itemA := levelsA[lastA].items[idxA[lastA]]
+	itemB := levelsB[lastB].items[idxB[lastB]]
+	if itemA == itemB {
+		return nil
+	}
+
+	// An item can't be swapped with one it contains.
+	for _, level := range levelsA {
+		if level == itemB.inner {
+			return l.itemError(a, InvalidValues)
+		}
+	}
+	for _, level := range levelsB {
+		if level == itemA.inner {
+			return l.itemError(b, InvalidValues)
+		}
+	}
+
+	l.record()
+	levelsA[lastA].items[idxA[lastA]] = itemB
+	levelsB[lastB].items[idxB[lastB]] = itemA
+	itemA.ratio, itemB.ratio = itemB.ratio, itemA.ratio
+	itemA.fixed, itemB.fixed = itemB.fixed, itemA.fixed
+
+	return nil
+}
+
+// ToggleItem finds the item with the specified name within the layout (or
+// sublayouts), and toggles its visibility.
+func (l *layoutLevel) ToggleItem(name string) error {
+	i, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	if err := l.vetoHide(i); err != nil {
+		return err
+	}
+
+	l.record()
+	i.hidden = !i.hidden
+
+	return nil
+}
+
+// ToggleItem finds the item with the specified name within the layout (or
+// sublayouts), and sets its visibility to the provided value.
+func (l *layoutLevel) HideItem(name string, hidden HideLayout) error {
+	i, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	if hidden {
+		if err := l.vetoHide(i); err != nil {
+			return err
+		}
+	}
+
+	l.record()
+	i.hidden = hidden
+
+	return nil
+}
+
+// ResizeItem changes the space allocated for the named item. Either a fixed or
+// a ratio value must be provided, but not both.
+func (l *layoutLevel) ResizeItem(name string, ratio, fixed int) error {
+	i, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	if (ratio != 0) == (fixed != 0) || ratio < 0 || fixed < 0 {
+		return l.itemError(name, InvalidValues)
+	}
+
+	l.record()
+	i.ratio = ratio
+	i.fixed = fixed
+
+	return nil
+}
+
+// GrowItem changes the space allocated for the named item by delta lines or
+// columns, which may be negative. Fixed items simply change their size. For
+// ratio items, the weights of the item and its siblings are adjusted so that
+// the item grows by delta from its last rendered size, at the expense of the
+// other ratio items. Items never shrink below a single line or column.
+func (l *layoutLevel) GrowItem(name string, delta int) error {
+	levels, idx, err := l.findPath(name)
+	if err != nil {
+		return err
+	}
+
+	l.record()
+	level := levels[len(levels)-1]
+	i := level.items[idx[len(idx)-1]]
+	if i.fixed > 0 {
+		i.fixed += delta
+		if i.fixed < 1 {
+			i.fixed = 1
+		}
+		return nil
+	}
+
+	// Without a previous render, the weight itself is adjusted.
+	if i.size == 0 || i.isHidden() {
+		i.ratio += delta
+		if i.ratio < 1 {
+			i.ratio = 1
+		}
+		return nil
+	}
+
+	// Space taken since by fixed items is taken from the other ratio items
+	// too.
+	taken := -level.ratioSpace()
+	sizes := level.weighBySizes()
+	taken += sizes
+	others := sizes - i.size
+	for idx, item := range level.items {
+		if item.fixed > 0 || level.hidden(idx) {
+			continue
+		}
+		if item == i {
+			item.ratio = i.size + delta
+		} else if others > 0 {
+			item.ratio = item.size - (delta+taken)*item.size/others
+		}
+		if item.ratio < 1 {
+			item.ratio = 1
+		}
+	}
+	level.reduceWeights()
+
+	return nil
+}
+
+// ratioSpace returns the space the visible ratio items of the level had when
+// last rendered, less what the fixed items have grown by since.
+func (l *layoutLevel) ratioSpace() int {
+	space := 0
+	for _, item := range l.items {
+		if item.isHidden() || item.size == 0 {
+			continue
+		}
+		if item.fixed > 0 {
+			space -= item.fixed - item.size
+		} else {
+			space += item.size
+		}
+	}
+	return space
+}
+
+// weighBySizes switches the level's visible ratio items to weights matching
+// the sizes they were last rendered at, and scales those of the hidden ones
+// to match. The weights are then shared out in proportion, so that they fit
+// any space. It returns the space the visible ratio items took.
+func (l *layoutLevel) weighBySizes() int {
+	var weights, sizes int
+	for idx, item := range l.items {
+		if item.fixed == 0 && !l.hidden(idx) {
+			weights += item.ratio
+			sizes += item.size
+		}
+	}
+	if weights == 0 || sizes == 0 {
+		return 0
+	}
+
+	for idx, item := range l.items {
+		if item.fixed > 0 {
+			continue
+		}
+		if l.hidden(idx) {
+			item.ratio = item.ratio * sizes / weights
+		} else {
+			item.ratio = item.size
+		}
+		if item.ratio < 1 {
+			item.ratio = 1
+		}
+	}
+	l.proportional = true
+	return sizes
+}
+
+// reduceWeights divides the weights of the level's ratio items by their
+// greatest common divisor, keeping them as small as their proportions allow.
+func (l *layoutLevel) reduceWeights() {
+	d := 0
+	for _, item := range l.items {
+		if item.fixed == 0 {
+			d = gcd(d, item.ratio)
+		}
+	}
+	if d <= 1 {
+		return
+	}
+	for _, item := range l.items {
+		if item.fixed == 0 {
+			item.ratio /= d
+		}
+	}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Equalize gives all the ratio items of the level held by the named item, or
+// of the layout itself if levelName is empty, the same weight, discarding any
+// resizing done since they were created. A splitter is reset to an even split.
+// Fixed items are not changed.
+func (l *layoutLevel) Equalize(levelName string) error {
+	level, err := l.levelOf(levelName)
+	if err != nil {
+		return err
+	}
+
+	l.record()
+	level.equalize()
+
+	return nil
+}
+
+// equalize gives the level's ratio items the same weight, and splitters an
+// even split.
+func (l *layoutLevel) equalize() {
+	for _, item := range l.items {
+		if item.fixed == 0 {
+			item.ratio = 1
+		}
+	}
+	l.proportional = false
+	if l.splitter != nil {
+		l.splitter.percent = 50
+	}
+	l.changed()
+}
+
+// SetDirection changes the direction in which the items of the level held by
+// the named item, or of the layout itself if levelName is empty, are spread.
+func (l *layoutLevel) SetDirection(levelName string, direction LayoutDirection) error {
+	level, err := l.levelOf(levelName)
+	if err != nil {
+		return err
+	}
+
+	l.record()
+	level.direction = direction
+
+	return nil
+}
+
+// sizeOf returns the ratio and fixed size to use for the item at idx, when
+// the level has the given length to spread.
+func (l *layoutLevel) sizeOf(idx, length int) (ratio, fixed int) {
+	if l.splitter != nil && len(l.items) == 2 {
+		return l.splitter.sizeOf(l, idx, length)
+	}
+	return l.items[idx].ratio, l.items[idx].fixed
+}
+
+// shareOut divides length between the visible ratio items of a proportional
+// level, in proportion to their weights, which add up to segments. If that
+// would leave any of the count items without space, each gets a line or
+// column first, and the rest is shared out.
+func (l *layoutLevel) shareOut(sizes []int, total, length, segments, count int) {
+	base, rest := 0, length
+	for {
+		weight, prev := 0, 0
+		short := false
+		for i := range l.items {
+			if l.hidden(i) {
+				continue
+			}
+			ratio, fixed := l.sizeOf(i, total)
+			if fixed > 0 {
+				continue
+			}
+			weight += ratio
+			cur := rest * weight / segments
+			sizes[i] = base + cur - prev
+			short = short || sizes[i] < 1
+			prev = cur
+		}
+		if !short || base > 0 {
+			return
+		}
+		base, rest = 1, length-count
+	}
+}
+
+// viewNames returns the names of all the views created by the level and its
+// sublevels.
+func (l *layoutLevel) viewNames() []string {
+	return l.appendViewNames(nil)
+}
+
+func (l *layoutLevel) appendViewNames(names []string) []string {
+	for _, item := range l.items {
+		names = item.appendViewNames(names)
+	}
+	if l.splitter != nil && l.splitter.drag {
+		names = append(names, l.splitter.sash)
+	}
+	for name := range l.sashes {
+		names = append(names, name)
+	}
+	for name := range l.grips {
+		names = append(names, name)
+	}
+	if l.takeover != nil {
+		names = l.takeover.appendViewNames(names)
+	}
+	for _, m := range l.modals {
+		names = m.level.appendViewNames(names)
+	}
+	for name := range l.buttonViews {
+		names = append(names, name)
+	}
+	return names
+}
+
+// dropViews marks views to be deleted on the next layout.
+func (l *layoutLevel) dropViews(names []string) {
+	l.stale = append(l.stale, names...)
+}
+
+// deleteStale deletes the views dropped since the last layout, unless they are
+// still part of the tree.
+func (l *layoutLevel) deleteStale(g *gocui.Gui, deferred bool) error {
+	if len(l.stale) == 0 {
+		return nil
+	}
+
+	keep := l.keep()
+	for _, name := range l.stale {
+		if keep[name] {
+			continue
+		}
+		if err := deleteView(g, name, l.destroys[name], deferred); err != nil {
+			return err
+		}
+	}
+	l.stale = nil
+	l.destroys = nil
+
+	return nil
+}
+
+// hidden reports if the item at idx should not be displayed.
+func (l *layoutLevel) hidden(idx int) HideLayout {
+	if l.carousel != nil && idx != l.carousel.current {
+		return LayoutHidden
+	}
+	return l.items[idx].isHidden()
+}
+
+func (l *layoutLevel) allHidden() HideLayout {
+	for i := range l.items {
+		if !l.hidden(i) {
+			return LayoutVisible
+		}
+	}
+	return LayoutHidden
+}
+
+// placement is where an item of a level is placed, and the number of lines or
+// columns it was given.
+type placement struct {
+	rect   Rect
+	size   int
+	hidden bool
+}
+
+// arrange works out where each of the level's items goes within the given
+// rectangle. Hidden items are placed over the whole rectangle.
+func (l *layoutLevel) arrange(x0, y0, x1, y1, overlap int, forceHidden HideLayout) ([]placement, error) {
+	var length, acc int
+
+	// Figure out which dimention we care about
+	if l.direction == LayoutHorizontal {
+		length = x1 - x0 + 1
+		acc = x0
+	} else {
+		length = y1 - y0 + 1
+		acc = y0
+	}
+
+	total := length
+
+	// Add up all the (visible) fixed sizes, as they're not available for assignment
+	fixed := 0
+	segments := 0
+	ratioItems := 0
+	lastVisible := 0
+	for i := range l.items {
+		if forceHidden || l.hidden(i) {
+			continue
+		}
+		ratio, fixedSize := l.sizeOf(i, length)
+		if fixedSize > 0 {
+			fixed += fixedSize
+		} else {
+			segments += ratio
+			ratioItems++
+		}
+		lastVisible = i
+	}
+	if length < fixed {
+		return nil, fmt.Errorf("%w for fixed sizes: %d < %d", TooSmall, length, fixed)
+	}
+	length -= fixed
+
+	// The rest of the space gets split between the segments. Without any, it
+	// all goes to the last item.
+	unit := -1
+	left := length
+	if segments > 0 {
+		unit = l.rounding.unit(length, segments)
+		left = length - unit*segments
+	}
+
+	sizes := make([]int, len(l.items))
+	if l.proportional && segments > 0 {
+		if length < ratioItems {
+			return nil, fmt.Errorf("%w for allocated units: length=%d, items=%d", TooSmall, length, ratioItems)
+		}
+		l.shareOut(sizes, total, length, segments, ratioItems)
+		unit, left = 0, 0
+	} else if unit == 0 {
+		return nil, fmt.Errorf("%w for allocated units: length=%d, segments=%d", TooSmall, length, segments)
+	}
+
+	var ratioIdx []int
+	for idx := range l.items {
+		if forceHidden || l.hidden(idx) {
+			continue
+		}
+		if ratio, fixedSize := l.sizeOf(idx, total); fixedSize > 0 {
+			sizes[idx] = fixedSize
+		} else if !l.proportional {
+			sizes[idx] = unit * ratio
+			ratioIdx = append(ratioIdx, idx)
+		}
+	}
+
+	// The last item gets the leftovers, unless set otherwise.
+	if left > 0 && (l.remainder == RemainderLast || len(ratioIdx) == 0) {
+		sizes[lastVisible] += left
+	} else if left != 0 && !l.remainder.attribute(sizes, ratioIdx, left) {
+		return nil, fmt.Errorf("%w for allocated units: length=%d, segments=%d", TooSmall, length, segments)
+	}
+
+	places := make([]placement, len(l.items))
+	for idx := range l.items {
+		if forceHidden || l.hidden(idx) {
+			places[idx] = placement{rect: Rect{x0, y0, x1, y1}, hidden: true}
+			continue
+		}
+
+		assignment := sizes[idx]
+		ix0, ix1, iy0, iy1 := x0, x1, y0, y1
+		if l.direction == LayoutHorizontal {
+			ix0 = acc
+			ix1 = acc + assignment - overlap
+			if ix1 > x1 {
+				ix1 = x1
+			}
+		} else {
+			iy0 = acc
+			iy1 = acc + assignment - overlap
+			if iy1 > y1 {
+				iy1 = y1
+			}
+		}
+		acc += assignment
+		places[idx] = placement{rect: Rect{ix0, iy0, ix1, iy1}, size: assignment}
+	}
+
+	return places, nil
+}
+
+// pass holds the settings of the layout being rendered, which apply to all of
+// its levels.
+type pass struct {
+	// If set, errors rendering an item are added to it, and the rest of the
+	// items are still rendered. See ContinueOnError.
+	errs *Errors
+
+	// How the views of hidden items are created, see SetHiddenViews.
+	hidden HiddenViews
+
+	// The version of the whole tree, for cached geometry.
+	version uint64
+
+	// Shared by the levels when views don't overlap.
+	zeros []byte
+
+	// The item displayed over the whole layout, see Zoom.
+	zoomed *layoutItem
+
+	// The layout being rendered, which records the changes made by dragging
+	// with the mouse, to be undone.
+	root *layoutLevel
+
+	// Whether the borders between items can be dragged, see
+	// EnableDragBorders.
+	dragBorders bool
+
+	// Whether items can be moved with the mouse, see EnableDragItems.
+	dragItems bool
+
+	// Whether callbacks are called through the gui's Update, see
+	// DeferCallbacks.
+	deferred bool
+
+	// What is done when an item's create or update function fails, see
+	// SetItemErrors.
+	itemErrors ItemErrors
+
+	// How long views take to move, see AnimateResize, when this render
+	// happens, and whether any view is still on its way.
+	animate   time.Duration
+	easing    Easing
+	now       time.Time
+	animating bool
+
+	// Whether items shown or hidden slide in and out, see SlideToggled.
+	slide bool
+
+	// Counts what the render does, see OnStats.
+	stats *Stats
+}
+
+// noOverlaps returns overlaps for n items that share no edges.
+func (p *pass) noOverlaps(n int) []byte {
+	if len(p.zeros) < n {
+		p.zeros = make([]byte, n)
+	}
+	return p.zeros[:n]
+}
+
+// frame is a level being rendered. Rather than recursing into inner levels,
+// layout keeps a stack of frames, so that deep trees don't cost a call chain
+// per level, and the stack is reused between renders.
+type frame struct {
+	level       *layoutLevel
+	rect        Rect
+	edges       byte
+	forceHidden HideLayout
+
+	// Where the items go, once the takeover, if any, was rendered.
+	places   []placement
+	overlaps []byte
+
+	// The item being rendered, and the errors collected before it.
+	idx  int
+	mark int
+
+	// Whether the zoomed item, if any, is displayed over this level.
+	zoom bool
+
+	state frameState
+}
+
+type frameState int
+
+const (
+	frameEnter    frameState = iota // Not started yet
+	frameTakeover                   // Waiting for the takeover level
+	frameItems                      // Rendering the items
+	frameInner                      // Waiting for the inner level of an item
+	frameZoomed                     // Waiting for the level of the zoomed item
+)
+
+// layout renders the level within the given rectangle, the edges of which are
+// shared with other views as set in edges.
+func (l *layoutLevel) layout(g *gocui.Gui, x0, y0, x1, y1 int, edges byte, forceHidden HideLayout, p *pass) error {
+	overlap := 0
+	if !g.SupportOverlaps {
+		overlap = 1
+	}
+
+	if l.takeover == nil {
+		p.zoomed = l.zoomed
+	}
+
+	stack := append(l.frames[:0], frame{level: l, rect: Rect{x0, y0, x1, y1}, edges: edges, forceHidden: forceHidden, zoom: true})
+	var result error
+	for len(stack) > 0 {
+		next, push, err := stack[len(stack)-1].step(g, overlap, result, p)
+		if push {
+			stack = append(stack, next)
+			result = nil
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		result = err
+	}
+
+	// Drop the references to the levels, but keep the space.
+	stack = stack[:cap(stack)]
+	for i := range stack {
+		stack[i] = frame{}
+	}
+	l.frames = stack[:0]
+
+	return result
+}
+
+// step renders the frame until it needs another level rendered first, which it
+// returns to be pushed, or until it's done. result is the outcome of the last
+// level it asked for.
+func (f *frame) step(g *gocui.Gui, overlap int, result error, p *pass) (next frame, push bool, err error) {
+	l := f.level
+	r := f.rect
+
+	if f.state == frameEnter {
+		if l.batch > 0 {
+			return frame{}, false, nil
+		}
+		l.rect = r
+
+		if err := l.deleteStale(g, p.deferred); err != nil {
+			return frame{}, false, fmt.Errorf("error deleting views: %w", err)
+		}
+
+		f.state = frameTakeover
+		if l.takeover != nil {
+			return frame{level: l.takeover, rect: r, edges: f.edges, forceHidden: f.forceHidden}, true, nil
+		}
+	}
+
+	if f.state == frameZoomed {
+		return frame{}, false, f.itemDone(p.zoomed, result, p)
+	}
+
+	if f.state == frameTakeover {
+		if result != nil {
+			return frame{}, false, fmt.Errorf("error creating layout: %w", result)
+		}
+		if l.takeover != nil || (f.zoom && p.zoomed != nil) {
+			f.forceHidden = LayoutHidden
+		}
+
+		places, cached, err := l.place(r.X0, r.Y0, r.X1, r.Y1, overlap, f.forceHidden, p.version)
+		if err != nil {
+			return frame{}, false, err
+		}
+		p.stats.Levels++
+		if cached {
+			p.stats.Cached++
+		}
+		f.places = places
+		if g.SupportOverlaps {
+			f.overlaps = l.overlaps(places, f.edges)
+		} else {
+			f.overlaps = p.noOverlaps(len(places))
+		}
+		f.state = frameItems
+	}
+
+	if f.state == frameInner {
+		if err := f.itemDone(l.items[f.idx], result, p); err != nil {
+			return frame{}, false, err
+		}
+		f.idx++
+		f.state = frameItems
+	}
+
+	for ; f.idx < len(l.items); f.idx++ {
+		item := l.items[f.idx]
+		if item == p.zoomed || item.float != nil {
+			continue
+		}
+		p.stats.Items++
+		if p.errs != nil {
+			f.mark = len(*p.errs)
+		}
+
+		// Make sure we still create all the views, even if they're not visible
+		var err error
+		if f.places[f.idx].hidden {
+			item.displayed = false
+			if item.inner != nil {
+				f.state = frameInner
+				return frame{level: item.inner, rect: r, forceHidden: LayoutHidden}, true, nil
+			} else if item.manager == nil {
+				var sliding bool
+				if sliding, err = item.slideOut(g, r, l.direction, p); !sliding && err == nil {
+					err = item.hiddenView(g, r.X0, r.Y0, r.X1, r.Y1, p)
+				}
+			}
+		} else {
+			ir := f.places[f.idx].rect
+			item.rect = ir
+			item.displayed = true
+			item.size = f.places[f.idx].size
+
+			if item.inner != nil {
+				f.state = frameInner
+				return frame{level: item.inner, rect: ir, edges: f.overlaps[f.idx], forceHidden: LayoutVisible}, true, nil
+			} else if item.manager != nil {
+				err = layoutManager(g, item.manager, ir.X0, ir.Y0, ir.X1, ir.Y1)
+			} else {
+				item.slideIn(ir, r, l.direction, p)
+				err = item.createView(g, ir.X0, ir.Y0, ir.X1, ir.Y1, f.overlaps[f.idx], p)
+			}
+		}
+
+		if err := f.itemDone(item, err, p); err != nil {
+			return frame{}, false, err
+		}
+	}
+
+	if l.splitter != nil {
+		if err := l.splitter.layoutSash(g, l, f.forceHidden); err != nil {
+			return frame{}, false, fmt.Errorf("error creating layout: %w", err)
+		}
+	}
+	if err := l.layoutSashes(g, p, f.forceHidden); err != nil {
+		return frame{}, false, fmt.Errorf("error creating layout: %w", err)
+	}
+	if err := l.layoutGrips(g, p, f.forceHidden); err != nil {
+		return frame{}, false, fmt.Errorf("error creating layout: %w", err)
+	}
+
+	if f.zoom && p.zoomed != nil {
+		// The zoomed item is displayed over the whole level, after the
+		// rest was hidden.
+		item := p.zoomed
+		item.rect = r
+		item.displayed = true
+		p.stats.Items++
+		if p.errs != nil {
+			f.mark = len(*p.errs)
+		}
+
+		var err error
+		if item.inner != nil {
+			f.state = frameZoomed
+			return frame{level: item.inner, rect: r, edges: f.edges, forceHidden: LayoutVisible}, true, nil
+		} else if item.manager != nil {
+			err = layoutManager(g, item.manager, r.X0, r.Y0, r.X1, r.Y1)
+		} else {
+			err = item.createView(g, r.X0, r.Y0, r.X1, r.Y1, f.edges, p)
+		}
+		return frame{}, false, f.itemDone(item, err, p)
+	}
+
+	return frame{}, false, nil
+}
+
+// itemDone handles the outcome of rendering an item, returning the error to
+// stop rendering with, if any.
+func (f *frame) itemDone(item *layoutItem, err error, p *pass) error {
+	if p.errs != nil {
+		p.errs.collect(item, f.mark, err)
+	} else if err != nil {
+		return wrapItemError(item, err)
+	}
+	return nil
+}
+
+func (l *layoutLevel) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	return l.LayoutRect(g, 0, 0, maxX-1, maxY-1)
+}
+
+// createView creates or updates the item's view, keeping the contents of the
+// view it had before being renamed or hidden.
+func (l *layoutItem) createView(g *gocui.Gui, x0, y0, x1, y1 int, overlaps byte, p *pass) error {
+	if err := l.migrateView(g); err != nil {
+		return err
+	}
+	if err := l.restoreView(g, x0, y0, x1, y1, overlaps); err != nil {
+		return err
+	}
+	r := Rect{x0, y0, x1, y1}
+	update := l.shouldUpdate(r)
+	fNew, fUpdate := l.fNew, l.fUpdate
+	if !update {
+		fUpdate = nil
+	}
+	if p.itemErrors != ItemErrorsAbort {
+		fNew, fUpdate = l.isolate(fNew, p.itemErrors), l.isolate(fUpdate, p.itemErrors)
+	}
+	if p.deferred {
+		fNew, fUpdate = l.deferView(g, fNew), l.deferView(g, fUpdate)
+	}
+	first := false
+	if l.fUpdateCtx != nil {
+		_, err := g.View(l.name)
+		first = err != nil
+	}
+	at := l.place(r, p)
+	p.stats.Views++
+	if at != l.viewRect {
+		p.stats.Moved++
+		l.viewRect = at
+	}
+	v, err := createView(g, l.name, at.X0, at.Y0, at.X1, at.Y1, overlaps, fNew, fUpdate)
+	if err != nil {
+		return err
+	}
+	v.Overlaps = overlaps
+	if l.hasTitle {
+		v.Title = l.title
+	}
+
+	if l.fUpdateCtx != nil && (first || update) {
+		info := l.updateInfo(g, r, first)
+		f := func(v *gocui.View) error {
+			return l.fUpdateCtx(g, v, info)
+		}
+		if p.itemErrors != ItemErrorsAbort {
+			f = l.isolate(f, p.itemErrors)
+		}
+		if err := call(g, p.deferred, l, func() error {
+			return f(v)
+		}); err != nil {
 			return err
 		}
+	}
+
+	// The views of hidden items are placed out of the way instead.
+	if l.fResize != nil && l.displayed && r != l.resizedRect {
+		old := l.resizedRect
+		l.resizedRect = r
+		// Views are never displayed in the zero Rect, which marks one
+		// that wasn't displayed yet.
+		if old != (Rect{}) {
+			return call(g, p.deferred, l, func() error {
+				return l.fResize(v, old, r)
+			})
+		}
+	}
+	return nil
+}
+
+// updateInfo describes the item for its update function, with its view
+// placed in r.
+func (l *layoutItem) updateInfo(g *gocui.Gui, r Rect, first bool) ItemInfo {
+	info := ItemInfo{
+		Name:    l.name,
+		Hidden:  l.hidden,
+		Visible: l.displayed,
+		Ratio:   l.ratio,
+		Fixed:   l.fixed,
+		Rect:    r,
+		First:   first,
+	}
+	if v := g.CurrentView(); v != nil && v.Name() == l.name {
+		info.Focused = true
+	}
+	return info
+}
+
+// shouldUpdate reports if the update function is to be called for a view
+// placed in r.
+func (l *layoutItem) shouldUpdate(r Rect) bool {
+	if !l.updateOnResize && l.updateEvery == 0 {
+		return true
+	}
+
+	update := false
+	if l.updateOnResize && r != l.updatedRect {
+		update = true
+	}
+	if l.updateEvery > 0 && time.Since(l.updatedAt) >= l.updateEvery {
+		update = true
+	}
+	if update {
+		l.updatedRect = r
+		l.updatedAt = time.Now()
+	}
+	return update
+}
+
+func createView(g *gocui.Gui, name string, x0, y0, x1, y1 int, overlaps byte,
+	fNew func(*gocui.View) error,
+	fUpdate func(*gocui.View) error) (*gocui.View, error) {
+	v, err := g.SetView(name, x0, y0, x1, y1, overlaps)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return nil, err
+		}
 		v.Frame = true
 		v.Autoscroll = false
 		if fNew != nil {
-			return fNew(v)
+			return v, fNew(v)
 		}
 	} else if fUpdate != nil {
-		return fUpdate(v)
+		return v, fUpdate(v)
 	}
-	return nil
+	return v, nil
 }
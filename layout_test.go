@@ -1,7 +1,10 @@
 package layout
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -356,3 +359,919 @@ func runTests(t *testing.T, g *gocui.Gui, tests []testCase) {
 	}
 	<-time.After(50 * time.Millisecond)
 }
+
+// newTestGui creates a gui on the simulated screen, for tests that call
+// Layout directly rather than running the main loop.
+func newTestGui(t testing.TB, overlap bool) *gocui.Gui {
+	t.Helper()
+	g, err := gocui.NewGui(gocui.OutputSimulator, overlap)
+	if err != nil {
+		t.Fatalf("Can't create gui: %v", err)
+	}
+	return g
+}
+
+// checkViews verifies the dimensions of the listed views.
+func checkViews(t *testing.T, g *gocui.Gui, wants map[string]size) {
+	t.Helper()
+	for name, want := range wants {
+		v, err := g.View(name)
+		if err != nil {
+			t.Errorf("Expected view %q not found: %v", name, err)
+			continue
+		}
+		x0, y0, x1, y1 := v.Dimensions()
+		got := size{x0, y0, x1, y1}
+		if got != want {
+			t.Errorf("Unexpected size for %q: got %s, want %s", name, got.String(), want.String())
+		}
+	}
+}
+
+// describe returns a compact description of the tree's structure, such as
+// "H(a V(b c))", using container names only where they hold views.
+func describe(l *layoutLevel) string {
+	var parts []string
+	for _, item := range l.items {
+		if item.inner != nil {
+			parts = append(parts, describe(item.inner))
+		} else {
+			parts = append(parts, item.name)
+		}
+	}
+	d := "V"
+	if l.direction == LayoutHorizontal {
+		d = "H"
+	}
+	return d + "(" + strings.Join(parts, " ") + ")"
+}
+
+func TestRemoveItem(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewFixedItem(5, "header"),
+		NewRatioItem(1, "body", WithInner(NewLevel(LayoutHorizontal,
+			NewRatioItem(1, "left"),
+			NewRatioItem(1, "right"),
+		))),
+		NewFixedItem(5, "footer"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.RemoveItem("left"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"header": {0, 0, 79, 4},
+		"right":  {0, 5, 79, 19},
+		"footer": {0, 20, 79, 24},
+	})
+
+	if err := l.RemoveItem("right"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if got, want := describe(l), "V(header footer)"; got != want {
+		t.Errorf("Unexpected layout: got %s, want %s", got, want)
+	}
+	checkViews(t, g, map[string]size{
+		"header": {0, 0, 79, 4},
+		"footer": {0, 5, 79, 24},
+	})
+	if len(g.Views()) != 2 {
+		t.Errorf("Views not deleted: %v", g.Views())
+	}
+
+	if err := l.RemoveItem("left"); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for missing item: got %v, want %v", err, NotFound)
+	}
+}
+
+func TestAddItem(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "channels", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "general"),
+		))),
+	)
+
+	if err := l.AddItem("channels", -1, NewRatioItem(1, "random")); err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+	if err := l.AddItem("channels", 0, NewRatioItem(1, "announce")); err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+	if err := l.AddItem("", 1, NewFixedItem(20, "users")); err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+	if got, want := describe(l), "H(V(announce general random) users)"; got != want {
+		t.Errorf("Unexpected layout: got %s, want %s", got, want)
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"announce": {0, 0, 59, 7},
+		"general":  {0, 8, 59, 15},
+		"random":   {0, 16, 59, 24},
+		"users":    {60, 0, 79, 24},
+	})
+
+	errTests := []struct {
+		parent string
+		index  int
+		name   string
+		want   error
+	}{
+		{"channels", 0, "general", DuplicateName},
+		{"missing", 0, "new", NotFound},
+		{"users", 0, "new", NotContainer},
+		{"channels", 4, "new", InvalidValues},
+	}
+	for _, tc := range errTests {
+		if err := l.AddItem(tc.parent, tc.index, NewRatioItem(1, tc.name)); !errors.Is(err, tc.want) {
+			t.Errorf("AddItem(%q, %d, %q): got %v, want %v", tc.parent, tc.index, tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestSwapItems(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(20, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+
+	if err := l.SwapItems("a", "c"); err != nil {
+		t.Fatalf("SwapItems failed: %v", err)
+	}
+	if got, want := describe(l), "H(c V(b a))"; got != want {
+		t.Errorf("Unexpected layout: got %s, want %s", got, want)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"c": {0, 0, 19, 24},
+		"b": {20, 0, 79, 11},
+		"a": {20, 12, 79, 24},
+	})
+
+	if err := l.SwapItems("col", "b"); !errors.Is(err, InvalidValues) {
+		t.Errorf("Unexpected error swapping with a child: got %v, want %v", err, InvalidValues)
+	}
+	if err := l.SwapItems("a", "missing"); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for missing item: got %v, want %v", err, NotFound)
+	}
+}
+
+func TestSetInner(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "list"),
+		NewRatioItem(1, "detail", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "summary"),
+			NewRatioItem(1, "body"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.SetInner("detail", NewLevel(LayoutVertical,
+		NewRatioItem(1, "body"),
+		NewFixedItem(5, "actions"),
+	)); err != nil {
+		t.Fatalf("SetInner failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"list":    {0, 0, 39, 24},
+		"body":    {40, 0, 79, 19},
+		"actions": {40, 20, 79, 24},
+	})
+	if _, err := g.View("summary"); err != gocui.ErrUnknownView {
+		t.Errorf("View of the previous level was not deleted")
+	}
+
+	if err := l.SetInner("detail", nil); !errors.Is(err, InvalidValues) {
+		t.Errorf("Unexpected error for nil level: got %v, want %v", err, InvalidValues)
+	}
+}
+
+func TestReorderItems(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "grid", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+			NewRatioItem(1, "d"),
+		))),
+	)
+
+	if err := l.ReorderItems("grid", []string{"d", "b"}); err != nil {
+		t.Fatalf("ReorderItems failed: %v", err)
+	}
+	if err := l.ReorderItems("", []string{"grid", "a"}); err != nil {
+		t.Fatalf("ReorderItems failed: %v", err)
+	}
+	if got, want := describe(l), "H(V(d b c) a)"; got != want {
+		t.Errorf("Unexpected layout: got %s, want %s", got, want)
+	}
+
+	errTests := []struct {
+		level string
+		names []string
+		want  error
+	}{
+		{"grid", []string{"a"}, NotFound},
+		{"grid", []string{"b", "b"}, InvalidValues},
+		{"a", []string{"b"}, NotContainer},
+		{"missing", []string{"b"}, NotFound},
+	}
+	for _, tc := range errTests {
+		if err := l.ReorderItems(tc.level, tc.names); !errors.Is(err, tc.want) {
+			t.Errorf("ReorderItems(%q, %v): got %v, want %v", tc.level, tc.names, err, tc.want)
+		}
+	}
+}
+
+func TestResizeItem(t *testing.T) {
+	l := NewLevel(LayoutHorizontal, NewRatioItem(1, "a"))
+
+	tests := []struct {
+		ratio, fixed int
+		want         error
+	}{
+		{2, 0, nil},
+		{0, 10, nil},
+		{2, 10, InvalidValues},
+		{0, 0, InvalidValues},
+		{-1, 0, InvalidValues},
+		{0, -1, InvalidValues},
+	}
+	for _, tc := range tests {
+		if err := l.ResizeItem("a", tc.ratio, tc.fixed); !errors.Is(err, tc.want) {
+			t.Errorf("ResizeItem(%d, %d): got %v, want %v", tc.ratio, tc.fixed, err, tc.want)
+		}
+	}
+}
+
+func TestGrowItem(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(10, "fixed"),
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "c", Hidden()),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.GrowItem("fixed", 2); err != nil {
+		t.Fatalf("GrowItem failed: %v", err)
+	}
+	if err := l.GrowItem("a", 5); err != nil {
+		t.Fatalf("GrowItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"fixed": {0, 0, 11, 24},
+		"a":     {12, 0, 51, 24},
+		"b":     {52, 0, 79, 24},
+	})
+
+	// Growing the fixed item again leaves the ratio items less space than
+	// their weights add up to, which they share out in proportion.
+	if err := l.GrowItem("fixed", 20); err != nil {
+		t.Fatalf("GrowItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"fixed": {0, 0, 31, 24},
+		"a":     {32, 0, 59, 24},
+		"b":     {60, 0, 79, 24},
+	})
+
+	if err := l.GrowItem("fixed", -40); err != nil {
+		t.Fatalf("GrowItem failed: %v", err)
+	}
+	if l.items[0].fixed != 1 {
+		t.Errorf("Fixed item shrank below 1: %d", l.items[0].fixed)
+	}
+}
+
+func TestGrowItemThenShrink(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.GrowItem("a", 1); err != nil {
+		t.Fatalf("GrowItem failed: %v", err)
+	}
+
+	// The weights are kept in proportion, whatever the space.
+	for _, tc := range []struct {
+		w    int
+		want map[string]Rect
+	}{
+		{80, map[string]Rect{"a": {0, 0, 40, 24}, "b": {41, 0, 79, 24}}},
+		{79, map[string]Rect{"a": {0, 0, 39, 24}, "b": {40, 0, 78, 24}}},
+		{10, map[string]Rect{"a": {0, 0, 4, 24}, "b": {5, 0, 9, 24}}},
+		{2, map[string]Rect{"a": {0, 0, 0, 24}, "b": {1, 0, 1, 24}}},
+	} {
+		got, err := l.Compute(tc.w, 25)
+		if err != nil {
+			t.Errorf("Compute(%d) failed: %v", tc.w, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Compute(%d): got %v, want %v", tc.w, got, tc.want)
+		}
+	}
+}
+
+func TestMoreWeightThanSpace(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutVertical,
+		NewRatioItem(30, "a"),
+		NewRatioItem(10, "b"),
+	)
+	if err := l.Layout(g); !errors.Is(err, TooSmall) {
+		t.Errorf("Unexpected error: got %v, want %v", err, TooSmall)
+	}
+}
+
+func TestEqualize(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(20, "fixed"),
+		NewRatioItem(3, "a"),
+		NewRatioItem(1, "split", WithInner(NewSplitter(LayoutVertical, 20,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+
+	if err := l.Equalize(""); err != nil {
+		t.Fatalf("Equalize failed: %v", err)
+	}
+	if err := l.Equalize("split"); err != nil {
+		t.Fatalf("Equalize failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"fixed": {0, 0, 19, 24},
+		"a":     {20, 0, 49, 24},
+		"b":     {50, 0, 79, 11},
+		"c":     {50, 12, 79, 24},
+	})
+
+	if err := l.Equalize("a"); !errors.Is(err, NotContainer) {
+		t.Errorf("Unexpected error for an item without a level: got %v, want %v", err, NotContainer)
+	}
+}
+
+func TestSetItemOptions(t *testing.T) {
+	g := newTestGui(t, false)
+	updates := 0
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithTitle("first")),
+		NewRatioItem(1, "b"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	err := l.SetItemOptions("a", WithTitle("second"), WithUpdate(func(*gocui.View) error {
+		updates++
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("SetItemOptions failed: %v", err)
+	}
+	if err := l.SetItemOptions("b", Hidden()); err != nil {
+		t.Fatalf("SetItemOptions failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 79, 24},
+	})
+
+	v, err := g.View("a")
+	if err != nil {
+		t.Fatalf("View failed: %v", err)
+	}
+	if v.Title != "second" {
+		t.Errorf("Unexpected title: want %q, got %q", "second", v.Title)
+	}
+	if updates != 1 {
+		t.Errorf("Unexpected number of updates: want 1, got %d", updates)
+	}
+
+	// Undo restores the options, one change at a time.
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 39, 24},
+		"b": {40, 0, 79, 24},
+	})
+	if err := l.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v.Title != "first" {
+		t.Errorf("Unexpected title after undo: want %q, got %q", "first", v.Title)
+	}
+	if updates != 2 {
+		t.Errorf("Expected the update function to be restored, got %d updates", updates)
+	}
+	if err := l.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v.Title != "second" {
+		t.Errorf("Unexpected title after redo: want %q, got %q", "second", v.Title)
+	}
+
+	// An empty title clears it.
+	if err := l.SetItemOptions("a", WithTitle("")); err != nil {
+		t.Fatalf("SetItemOptions failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v.Title != "" {
+		t.Errorf("Expected the title to be cleared, got %q", v.Title)
+	}
+
+	if err := l.SetItemOptions("missing", Hidden()); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for a missing item: got %v, want %v", err, NotFound)
+	}
+}
+
+func TestSetFuncs(t *testing.T) {
+	g := newTestGui(t, false)
+	var calls []string
+	record := func(s string) func(*gocui.View) error {
+		return func(*gocui.View) error {
+			calls = append(calls, s)
+			return nil
+		}
+	}
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithUpdate(record("old update"))),
+		NewRatioItem(1, "b", Hidden()),
+		NewRatioItem(1, "c", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "d"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	calls = nil
+
+	if err := l.SetUpdateFunc("a", record("a update")); err != nil {
+		t.Fatalf("SetUpdateFunc failed: %v", err)
+	}
+	if err := l.SetCreateFunc("a", record("a create")); err != nil {
+		t.Fatalf("SetCreateFunc failed: %v", err)
+	}
+	if err := l.AddItem("", -1, NewRatioItem(1, "e")); err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+	if err := l.SetCreateFunc("e", record("e create")); err != nil {
+		t.Fatalf("SetCreateFunc failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	want := "a update, e create"
+	if got := strings.Join(calls, ", "); got != want {
+		t.Errorf("Unexpected calls: want %q, got %q", want, got)
+	}
+
+	if err := l.SetUpdateFunc("c", nil); !errors.Is(err, InvalidValues) {
+		t.Errorf("Unexpected error for a container: got %v, want %v", err, InvalidValues)
+	}
+	if err := l.SetCreateFunc("missing", nil); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for a missing item: got %v, want %v", err, NotFound)
+	}
+}
+
+func TestMergeLevel(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+		))),
+	)
+
+	tests := []struct {
+		desc   string
+		target string
+		other  *layoutLevel
+		err    error
+		want   string
+	}{
+		{
+			desc:   "into a level",
+			target: "b",
+			other:  NewLevel(LayoutHorizontal, NewRatioItem(1, "d"), NewFixedItem(5, "e")),
+			want:   "H(a V(c d e))",
+		},
+		{
+			desc:   "into the layout",
+			target: "",
+			other: NewLevel(LayoutHorizontal, NewRatioItem(1, "f", WithInner(NewLevel(LayoutVertical,
+				NewRatioItem(1, "g"),
+			)))),
+			want: "H(a V(c d e) V(g))",
+		},
+		{
+			desc:   "existing name",
+			target: "",
+			other:  NewLevel(LayoutHorizontal, NewRatioItem(1, "h"), NewRatioItem(1, "c")),
+			err:    DuplicateName,
+			want:   "H(a V(c d e) V(g))",
+		},
+		{
+			desc:   "repeated name",
+			target: "",
+			other:  NewLevel(LayoutHorizontal, NewRatioItem(1, "h"), NewRatioItem(1, "h")),
+			err:    DuplicateName,
+			want:   "H(a V(c d e) V(g))",
+		},
+		{
+			desc:   "not a level",
+			target: "a",
+			other:  NewLevel(LayoutHorizontal, NewRatioItem(1, "h")),
+			err:    NotContainer,
+			want:   "H(a V(c d e) V(g))",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := l.MergeLevel(tc.target, tc.other); !errors.Is(err, tc.err) {
+				t.Errorf("Unexpected error: got %v, want %v", err, tc.err)
+			}
+			if got := describe(l); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 25, 24},
+		"e": {26, 20, 51, 24},
+		"g": {52, 0, 79, 24},
+	})
+}
+
+func TestUserData(t *testing.T) {
+	type model struct{ id int }
+	m := &model{id: 3}
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithUserData(m)),
+		NewRatioItem(1, "b"),
+	)
+
+	if got, ok := l.UserData("a"); !ok || got != m {
+		t.Errorf("UserData(a): want %v, true, got %v, %v", m, got, ok)
+	}
+	if got, ok := l.UserData("b"); ok {
+		t.Errorf("UserData(b): want nothing, got %v", got)
+	}
+	if got, ok := l.UserData("missing"); ok {
+		t.Errorf("UserData(missing): want nothing, got %v", got)
+	}
+
+	if err := l.SetItemOptions("b", WithUserData("channel")); err != nil {
+		t.Fatalf("SetItemOptions failed: %v", err)
+	}
+	if got, ok := l.UserData("b"); !ok || got != "channel" {
+		t.Errorf("UserData(b): want channel, true, got %v, %v", got, ok)
+	}
+}
+
+func TestNamedLevel(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewNamedLevel("root", LayoutHorizontal,
+		NewRatioItem(1, "_left", WithInner(NewNamedLevel("sidebar", LayoutVertical,
+			NewRatioItem(1, "a"),
+			NewRatioItem(3, "b"),
+		))),
+		NewRatioItem(1, "c"),
+	)
+
+	if err := l.SetDirection("sidebar", LayoutHorizontal); err != nil {
+		t.Fatalf("SetDirection failed: %v", err)
+	}
+	if err := l.Equalize("sidebar"); err != nil {
+		t.Fatalf("Equalize failed: %v", err)
+	}
+	if err := l.ResizeItem("sidebar", 0, 20); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	if err := l.SetDirection("root", LayoutVertical); err != nil {
+		t.Fatalf("SetDirection failed: %v", err)
+	}
+	if got, want := describe(l), "V(H(a b) c)"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 39, 19},
+		"b": {40, 0, 79, 19},
+		"c": {0, 20, 79, 24},
+	})
+
+	if err := l.HideItem("sidebar", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"c": {0, 0, 79, 24},
+	})
+
+	if err := l.AddItem("", -1, NewRatioItem(1, "sidebar")); !errors.Is(err, DuplicateName) {
+		t.Errorf("Unexpected error adding a level's name: got %v, want %v", err, DuplicateName)
+	}
+}
+
+func TestUpdateModes(t *testing.T) {
+	g := newTestGui(t, false)
+	calls := make(map[string]int)
+	count := func(name string) layoutItemOption {
+		return WithUpdate(func(*gocui.View) error {
+			calls[name]++
+			return nil
+		})
+	}
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "always", count("always")),
+		NewRatioItem(1, "resize", count("resize"), WithUpdateOnResize()),
+		NewRatioItem(1, "timer", count("timer"), WithUpdateEvery(50*time.Millisecond)),
+	)
+
+	// Views are created on the first render, and updated on the next.
+	for i := 0; i < 3; i++ {
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	want := map[string]int{"always": 2}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Unexpected updates: want %v, got %v", want, calls)
+	}
+
+	if err := l.ResizeItem("always", 0, 10); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	want = map[string]int{"always": 4, "resize": 1, "timer": 1}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Unexpected updates: want %v, got %v", want, calls)
+	}
+}
+
+func TestWithUpdateCtx(t *testing.T) {
+	g := newTestGui(t, false)
+	var infos []ItemInfo
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithUpdateCtx(func(gui *gocui.Gui, v *gocui.View, info ItemInfo) error {
+			if gui != g || v.Name() != "a" {
+				t.Errorf("Unexpected arguments: %v, %q", gui, v.Name())
+			}
+			infos = append(infos, info)
+			return nil
+		})),
+		NewRatioItem(1, "b"),
+	)
+
+	for i := 0; i < 2; i++ {
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	if _, err := g.SetCurrentView("a"); err != nil {
+		t.Fatalf("SetCurrentView failed: %v", err)
+	}
+	if err := l.HideItem("a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	want := []ItemInfo{
+		{Name: "a", Visible: true, Ratio: 1, Rect: Rect{0, 0, 39, 24}, First: true},
+		{Name: "a", Visible: true, Ratio: 1, Rect: Rect{0, 0, 39, 24}},
+		{Name: "a", Hidden: LayoutHidden, Ratio: 1, Rect: Rect{0, 0, 79, 24}, Focused: true},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("Unexpected infos:\ngot  %+v\nwant %+v", infos, want)
+	}
+}
+
+func TestWithOnResize(t *testing.T) {
+	g := newTestGui(t, false)
+	var calls [][2]Rect
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithOnResize(func(v *gocui.View, old, new Rect) error {
+			if v.Name() != "a" {
+				t.Errorf("Unexpected view: %q", v.Name())
+			}
+			calls = append(calls, [2]Rect{old, new})
+			return nil
+		})),
+		NewRatioItem(1, "b"),
+	)
+
+	// Not called when created, nor when nothing moved.
+	for i := 0; i < 2; i++ {
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+	if len(calls) != 0 {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	if err := l.ResizeItem("b", 0, 20); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	want := [][2]Rect{{{0, 0, 39, 24}, {0, 0, 59, 24}}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Unexpected calls: got %v, want %v", calls, want)
+	}
+
+	// Hidden and shown again elsewhere.
+	if err := l.HideItem("a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.SwapItems("a", "b"); err != nil {
+		t.Fatalf("SwapItems failed: %v", err)
+	}
+	if err := l.HideItem("a", LayoutVisible); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	want = append(want, [2]Rect{{0, 0, 59, 24}, {60, 0, 79, 24}})
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Unexpected calls: got %v, want %v", calls, want)
+	}
+}
+
+// benchGrid returns a layout of rows by cols views.
+func benchGrid(rows, cols int) *layoutLevel {
+	l := NewLevel(LayoutVertical)
+	for r := 0; r < rows; r++ {
+		row := NewLevel(LayoutHorizontal)
+		for c := 0; c < cols; c++ {
+			row.items = append(row.items, NewRatioItem(1, fmt.Sprintf("v%d-%d", r, c)))
+		}
+		l.items = append(l.items, NewRatioItem(1, fmt.Sprintf("row%d", r), WithInner(row)))
+	}
+	return l
+}
+
+// benchDeep returns a layout nested depth levels deep, each level holding a
+// view next to the next level.
+func benchDeep(depth int) *layoutLevel {
+	var inner *layoutLevel
+	for d := depth - 1; d >= 0; d-- {
+		dir := LayoutHorizontal
+		if d%2 == 1 {
+			dir = LayoutVertical
+		}
+		l := NewLevel(dir, NewFixedItem(3, fmt.Sprintf("v%d", d)))
+		if inner != nil {
+			l.items = append(l.items, NewRatioItem(1, fmt.Sprintf("l%d", d), WithInner(inner)))
+		}
+		inner = l
+	}
+	return inner
+}
+
+func benchmarkLayout(b *testing.B, l *layoutLevel, overlap bool) {
+	g := newTestGui(b, overlap)
+	if err := l.LayoutRect(g, 0, 0, 999, 999); err != nil {
+		b.Fatalf("LayoutRect failed: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := l.LayoutRect(g, 0, 0, 999, 999); err != nil {
+			b.Fatalf("LayoutRect failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkLayoutGrid(b *testing.B)         { benchmarkLayout(b, benchGrid(16, 16), false) }
+func BenchmarkLayoutGridOverlaps(b *testing.B) { benchmarkLayout(b, benchGrid(16, 16), true) }
+func BenchmarkLayoutDeep(b *testing.B)         { benchmarkLayout(b, benchDeep(200), false) }
+
+func TestLayoutDeep(t *testing.T) {
+	g := newTestGui(t, false)
+	l := benchDeep(200)
+	if err := l.LayoutRect(g, 0, 0, 999, 999); err != nil {
+		t.Fatalf("LayoutRect failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"v0":   {0, 0, 2, 999},
+		"v1":   {3, 0, 999, 2},
+		"v199": {300, 297, 999, 999},
+	})
+}
+
+func TestNewItemE(t *testing.T) {
+	tests := []struct {
+		desc  string
+		new   func(int, string, ...layoutItemOption) (*layoutItem, error)
+		size  int
+		valid bool
+	}{
+		{"ratio", NewRatioItemE, 2, true},
+		{"zero ratio", NewRatioItemE, 0, false},
+		{"negative ratio", NewRatioItemE, -1, false},
+		{"fixed", NewFixedItemE, 3, true},
+		{"zero fixed", NewFixedItemE, 0, false},
+		{"negative fixed", NewFixedItemE, -3, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			item, err := tc.new(tc.size, "a", WithTitle("A"))
+			if !tc.valid {
+				if !errors.Is(err, InvalidValues) {
+					t.Errorf("Expected InvalidValues, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if item.ratio+item.fixed != tc.size || item.title != "A" {
+				t.Errorf("Unexpected item: %+v", item)
+			}
+		})
+	}
+}
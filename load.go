@@ -0,0 +1,187 @@
+package layout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// levelSpec is the declarative form of a level, as read from a layout file,
+// in JSON or YAML.
+type levelSpec struct {
+	// Optional, see NewNamedLevel.
+	Name string `json:"name" yaml:"name"`
+	// "horizontal" or "vertical".
+	Direction string     `json:"direction" yaml:"direction"`
+	Items     []itemSpec `json:"items" yaml:"items"`
+}
+
+// itemSpec is the declarative form of an item, with exactly one of Ratio,
+// Fixed or Percent set.
+type itemSpec struct {
+	Name    string `json:"name" yaml:"name"`
+	Ratio   int    `json:"ratio" yaml:"ratio"`
+	Fixed   int    `json:"fixed" yaml:"fixed"`
+	Percent int    `json:"percent" yaml:"percent"`
+
+	Hidden bool     `json:"hidden" yaml:"hidden"`
+	Title  string   `json:"title" yaml:"title"`
+	Tags   []string `json:"tags" yaml:"tags"`
+
+	// The level the item holds, if any, see WithInner.
+	Level *levelSpec `json:"level" yaml:"level"`
+}
+
+// FromJSON builds a layout from its JSON description, such as a file letting
+// users customize the layout of an application:
+//
+//	{
+//	  "direction": "horizontal",
+//	  "items": [
+//	    {"name": "sidebar", "fixed": 30, "tags": ["nav"]},
+//	    {"name": "body", "ratio": 1, "level": {
+//	      "direction": "vertical",
+//	      "items": [
+//	        {"name": "editor", "percent": 70, "title": "Editor"},
+//	        {"name": "terminal", "percent": 30, "hidden": true}
+//	      ]
+//	    }}
+//	  ]
+//	}
+//
+// Each item takes a ratio, a fixed size or a percentage of the space left by
+// the fixed items of its level. The percentages of a level must add up to 100,
+// and can't be mixed with ratios. Functions, such as those of
+// WithCreate or WithUpdate, are attached afterwards by name, with
+// SetItemOptions. Unknown fields are rejected, and the layout is checked with
+// Validate, so that mistakes are reported rather than ignored.
+func FromJSON(data []byte) (*layoutLevel, error) {
+	var spec levelSpec
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("error reading layout: %w", err)
+	}
+	return spec.build()
+}
+
+// FromYAML builds a layout from its YAML description, which has the same
+// fields, and is checked the same way, as for FromJSON:
+//
+//	direction: horizontal
+//	items:
+//	  - name: sidebar
+//	    fixed: 30
+//	    tags: [nav]
+//	  - name: body
+//	    ratio: 1
+//	    level:
+//	      direction: vertical
+//	      items:
+//	        - {name: editor, percent: 70, title: Editor}
+//	        - {name: terminal, percent: 30, hidden: true}
+func FromYAML(data []byte) (*layoutLevel, error) {
+	var spec levelSpec
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return nil, fmt.Errorf("error reading layout: %w", err)
+	}
+	return spec.build()
+}
+
+// build creates the layout described by the spec, and checks it.
+func (s *levelSpec) build() (*layoutLevel, error) {
+	l, err := s.level(nil)
+	if err != nil {
+		return nil, err
+	}
+	return l.Build()
+}
+
+// level creates the level described by the spec, held by the items on path.
+func (s *levelSpec) level(path []string) (*layoutLevel, error) {
+	var direction LayoutDirection
+	switch s.Direction {
+	case "horizontal":
+		direction = LayoutHorizontal
+	case "vertical":
+		direction = LayoutVertical
+	default:
+		return nil, specError(s.Name, path, fmt.Errorf("%w: direction %q", InvalidValues, s.Direction))
+	}
+
+	l := NewNamedLevel(s.Name, direction)
+	percent, ratios := 0, 0
+	for i := range s.Items {
+		item, err := s.Items[i].item(path)
+		if err != nil {
+			return nil, err
+		}
+		l.items = append(l.items, item)
+		percent += s.Items[i].Percent
+		if s.Items[i].Ratio > 0 {
+			ratios++
+		}
+	}
+
+	// Percentages are shared out in proportion, whatever the space.
+	if percent > 0 {
+		if ratios > 0 {
+			return nil, specError(s.Name, path, fmt.Errorf("%w: percent mixed with ratio", InvalidValues))
+		}
+		if percent != 100 {
+			return nil, specError(s.Name, path, fmt.Errorf("%w: percents add up to %d, not 100", InvalidValues, percent))
+		}
+		l.proportional = true
+	}
+	l.reduceWeights()
+	return l, nil
+}
+
+// item creates the item described by the spec, in the level held by the
+// items on path.
+func (s *itemSpec) item(path []string) (*layoutItem, error) {
+	if s.Name == "" {
+		return nil, specError(s.Name, path, fmt.Errorf("%w: name is needed", InvalidValues))
+	}
+	sizes := 0
+	for _, size := range []int{s.Ratio, s.Fixed, s.Percent} {
+		if size < 0 {
+			return nil, specError(s.Name, path, fmt.Errorf("%w: size %d", InvalidValues, size))
+		}
+		if size > 0 {
+			sizes++
+		}
+	}
+	if sizes != 1 || s.Percent > 100 {
+		return nil, specError(s.Name, path,
+			fmt.Errorf("%w: one of ratio, fixed, or percent up to 100, is needed", InvalidValues))
+	}
+
+	opts := []layoutItemOption{WithTags(s.Tags...)}
+	if s.Title != "" {
+		opts = append(opts, WithTitle(s.Title))
+	}
+	if s.Hidden {
+		opts = append(opts, Hidden())
+	}
+	if s.Level != nil {
+		inner, err := s.Level.level(append(path[:len(path):len(path)], s.Name))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithInner(inner))
+	}
+
+	if s.Fixed > 0 {
+		return NewFixedItem(s.Fixed, s.Name, opts...), nil
+	}
+	return NewRatioItem(s.Ratio+s.Percent, s.Name, opts...), nil
+}
+
+// specError attributes an error in a layout description to the named item or
+// level, held by the items on path.
+func specError(name string, path []string, err error) error {
+	return &ItemError{Item: name, Path: append([]string(nil), path...), Err: err}
+}
@@ -0,0 +1,150 @@
+package layout
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFromJSON(t *testing.T) {
+	l, err := FromJSON([]byte(`{
+		"direction": "horizontal",
+		"items": [
+			{"name": "sidebar", "fixed": 20, "tags": ["nav"]},
+			{"name": "body", "ratio": 1, "level": {
+				"name": "main",
+				"direction": "vertical",
+				"items": [
+					{"name": "editor", "percent": 60, "title": "Editor", "tags": ["nav", "text"]},
+					{"name": "terminal", "percent": 40},
+					{"name": "help", "fixed": 3, "hidden": true}
+				]
+			}}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+
+	got, err := l.Compute(80, 25)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	want := map[string]Rect{
+		"sidebar":  {0, 0, 19, 24},
+		"body":     {20, 0, 79, 24},
+		"editor":   {20, 0, 79, 14},
+		"terminal": {20, 15, 79, 24},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected rects:\ngot  %v\nwant %v", got, want)
+	}
+	if got := l.Tagged("nav"); !reflect.DeepEqual(got, []string{"sidebar", "editor"}) {
+		t.Errorf("Unexpected tagged items: %v", got)
+	}
+	if item, _ := l.findItem("editor"); item.title != "Editor" {
+		t.Errorf("Unexpected title: %q", item.title)
+	}
+	if _, err := l.ParentOf("main"); err != nil {
+		t.Errorf("Expected the level to be named: %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		json string
+		item string
+		path []string
+	}{
+		{"bad direction", `{"direction": "sideways", "items": [{"name": "a", "ratio": 1}]}`, "", nil},
+		{"no size", `{"direction": "vertical", "items": [{"name": "a"}]}`, "a", nil},
+		{"two sizes", `{"direction": "vertical", "items": [{"name": "a", "ratio": 1, "fixed": 2}]}`, "a", nil},
+		{"too much", `{"direction": "vertical", "items": [{"name": "a", "percent": 120}]}`, "a", nil},
+		{"no name", `{"direction": "vertical", "items": [{"ratio": 1}]}`, "", nil},
+		{"percents short", `{"name": "l", "direction": "vertical", "items": [
+			{"name": "a", "percent": 50}, {"name": "b", "percent": 40}]}`, "l", nil},
+		{"percent with ratio", `{"name": "l", "direction": "vertical", "items": [
+			{"name": "a", "percent": 100}, {"name": "b", "ratio": 1}]}`, "l", nil},
+		{"nested", `{"direction": "vertical", "items": [{"name": "a", "ratio": 1, "level":
+			{"direction": "vertical", "items": [{"name": "b", "fixed": -1}]}}]}`, "b", []string{"a"}},
+	} {
+		_, err := FromJSON([]byte(tc.json))
+		var ie *ItemError
+		if !errors.As(err, &ie) || !errors.Is(err, InvalidValues) || ie.Item != tc.item || !reflect.DeepEqual(ie.Path, tc.path) {
+			t.Errorf("%s: unexpected error %v", tc.desc, err)
+		}
+	}
+
+	// Percentages are shared out in proportion, whatever the space.
+	l, err = FromJSON([]byte(`{"direction": "horizontal", "items": [
+		{"name": "a", "percent": 33}, {"name": "b", "percent": 67}]}`))
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	for _, tc := range []struct {
+		w    int
+		want map[string]Rect
+	}{
+		{80, map[string]Rect{"a": {0, 0, 25, 24}, "b": {26, 0, 79, 24}}},
+		{30, map[string]Rect{"a": {0, 0, 8, 24}, "b": {9, 0, 29, 24}}},
+	} {
+		got, err := l.Compute(tc.w, 25)
+		if err != nil {
+			t.Errorf("Compute(%d) failed: %v", tc.w, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Compute(%d): got %v, want %v", tc.w, got, tc.want)
+		}
+	}
+
+	if _, err := FromJSON([]byte(`{"direction": "vertical", "itmes": []}`)); err == nil {
+		t.Errorf("Expected unknown fields to be rejected")
+	}
+	_, err = FromJSON([]byte(`{"direction": "vertical", "items": [{"name": "a", "ratio": 1}, {"name": "a", "ratio": 1}]}`))
+	if !errors.Is(err, DuplicateName) {
+		t.Errorf("Expected DuplicateName, got %v", err)
+	}
+}
+
+func TestFromYAML(t *testing.T) {
+	l, err := FromYAML([]byte(`
+direction: horizontal
+items:
+  - name: sidebar
+    fixed: 20
+    tags: [nav]
+  - name: body
+    ratio: 1
+    level:
+      direction: vertical
+      items:
+        - {name: editor, percent: 60, title: Editor}
+        - {name: terminal, percent: 40, hidden: true}
+`))
+	if err != nil {
+		t.Fatalf("FromYAML failed: %v", err)
+	}
+	got, err := l.Compute(80, 25)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	want := map[string]Rect{
+		"sidebar": {0, 0, 19, 24},
+		"body":    {20, 0, 79, 24},
+		"editor":  {20, 0, 79, 24},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected rects:\ngot  %v\nwant %v", got, want)
+	}
+	if got := l.Tagged("nav"); !reflect.DeepEqual(got, []string{"sidebar"}) {
+		t.Errorf("Unexpected tagged items: %v", got)
+	}
+
+	if _, err := FromYAML([]byte("direction: vertical\nitmes: []\n")); err == nil {
+		t.Errorf("Expected unknown fields to be rejected")
+	}
+	_, err = FromYAML([]byte("direction: vertical\nitems:\n  - {name: a, ratio: 1, fixed: 1}\n"))
+	if !errors.Is(err, InvalidValues) {
+		t.Errorf("Expected InvalidValues, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// menuView is the name of the view listing the entries of a context menu.
+const menuView = "_layoutMenu"
+
+// MenuEntry is an entry of a context menu, see WithContextMenu. Action is
+// called with the name of the item the menu was opened on.
+type MenuEntry struct {
+	Label  string
+	Action func(g *gocui.Gui, item string) error
+}
+
+// WithContextMenu opens a menu of the given entries when the item's view is
+// right-clicked, at the mouse position. An entry is chosen with the arrow keys
+// and Enter, or by clicking on it, and Esc closes the menu. The menu is shown
+// as a modal, so the focus goes back to the view that had it once the menu
+// closes. When an entry is chosen, that view is focused before the entry's
+// action is called. The gui must have Mouse enabled.
+func WithContextMenu(entries ...MenuEntry) layoutItemOption {
+	return func(l *layoutItem) {
+		l.menu = entries
+	}
+}
+
+// itemKeys returns the keys to bind on the item's view: those set with
+// WithKeybinding, and the right click opening its context menu.
+func (l *layoutLevel) itemKeys(item *layoutItem) []itemKey {
+	if item.menu == nil {
+		return item.keys
+	}
+	return append(append([]itemKey(nil), item.keys...), itemKey{
+		gocui.MouseRight, gocui.ModNone,
+		func(g *gocui.Gui, v *gocui.View) error {
+			return l.openMenu(g, item, v)
+		},
+	})
+}
+
+// openMenu shows the context menu of the item, at the position of the mouse
+// within its view v, as placed by gocui.
+func (l *layoutLevel) openMenu(g *gocui.Gui, item *layoutItem, v *gocui.View) error {
+	entries := item.menu
+	width := 0
+	for _, e := range entries {
+		if n := len([]rune(e.Label)); n > width {
+			width = n
+		}
+	}
+
+	choose := func(g *gocui.Gui, v *gocui.View) error {
+		_, cy := v.Cursor()
+		if err := l.closeMenu(g); err != nil {
+			return err
+		}
+		if cy < 0 || cy >= len(entries) || entries[cy].Action == nil {
+			return nil
+		}
+		return entries[cy].Action(g, item.name)
+	}
+	move := func(step int) func(*gocui.Gui, *gocui.View) error {
+		return func(_ *gocui.Gui, v *gocui.View) error {
+			_, cy := v.Cursor()
+			if cy+step < 0 || cy+step >= len(entries) {
+				return nil
+			}
+			return v.SetCursor(0, cy+step)
+		}
+	}
+	menu := NewLevel(LayoutVertical, NewRatioItem(1, menuView,
+		WithCreate(func(v *gocui.View) error {
+			v.Highlight = true
+			v.SelBgColor, v.SelFgColor = gocui.ColorWhite, gocui.ColorBlack
+			labels := make([]string, len(entries))
+			for i, e := range entries {
+				labels[i] = e.Label
+			}
+			v.WriteString(strings.Join(labels, "\n"))
+			return nil
+		}),
+		WithKeybinding(gocui.KeyEnter, gocui.ModNone, choose),
+		WithKeybinding(gocui.MouseLeft, gocui.ModNone, choose),
+		WithKeybinding(gocui.KeyArrowUp, gocui.ModNone, move(-1)),
+		WithKeybinding(gocui.KeyArrowDown, gocui.ModNone, move(1)),
+		WithKeybinding(gocui.KeyEsc, gocui.ModNone, func(*gocui.Gui, *gocui.View) error {
+			l.CloseModal()
+			return nil
+		}),
+	))
+
+	x0, y0, _, _ := v.Dimensions()
+	cx, cy := v.Cursor()
+	l.showModal(g, &modalState{
+		level: menu,
+		w:     width + 2,
+		h:     len(entries) + 2,
+		x:     x0 + 1 + cx,
+		y:     y0 + 1 + cy,
+
+		placed: true,
+	})
+
+	return nil
+}
+
+// closeMenu closes the context menu, focusing the view that had the focus
+// before it right away, rather than once the layout is next rendered, if it
+// belongs to the layout's own items.
+func (l *layoutLevel) closeMenu(g *gocui.Gui) error {
+	var prev string
+	if m := l.topModal(); m != nil {
+		prev = m.prevFocus
+	}
+	l.CloseModal()
+	if l.topModal() != nil {
+		return nil
+	}
+
+	item, err := l.findItem(prev)
+	if err != nil || item.inner != nil || !l.displayed(item) {
+		return nil
+	}
+	l.focusName = ""
+	return l.focus(g, item)
+}
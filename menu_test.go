@@ -0,0 +1,88 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestContextMenu(t *testing.T) {
+	g := newTestGui(t, false)
+	var chosen []string
+	action := func(label string) func(*gocui.Gui, string) error {
+		return func(g *gocui.Gui, item string) error {
+			if cur := g.CurrentView(); cur == nil || cur.Name() != "a" {
+				t.Errorf("Expected a to have the focus back when %s is chosen, got %v", label, cur)
+			}
+			chosen = append(chosen, label+" "+item)
+			return nil
+		}
+	}
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithContextMenu(
+			MenuEntry{"Copy", action("copy")},
+			MenuEntry{"Paste", action("paste")},
+		)),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.SetCurrentView("a"); err != nil {
+		t.Fatalf("SetCurrentView failed: %v", err)
+	}
+	if n := len(l.keysBound["b"].keys); n != 1 {
+		t.Fatalf("Unexpected keys bound for b: got %d, want 1", n)
+	}
+
+	// Right-click near the bottom right corner of b.
+	v, err := g.View("b")
+	if err != nil {
+		t.Fatalf("View b not found: %v", err)
+	}
+	if err := v.SetCursorUnrestricted(35, 21); err != nil {
+		t.Fatalf("SetCursor failed: %v", err)
+	}
+	if err := l.keysBound["b"].keys[0].handler(g, v); err != nil {
+		t.Fatalf("Right click failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		menuView: {73, 21, 79, 24},
+	})
+	if cur := g.CurrentView(); cur == nil || cur.Name() != menuView {
+		t.Fatalf("Expected the menu to be focused, got %v", cur)
+	}
+
+	menu := l.Modal().items[0]
+	handler := func(key interface{}) func(*gocui.Gui, *gocui.View) error {
+		for _, k := range menu.keys {
+			if k.key == key {
+				return k.handler
+			}
+		}
+		t.Fatalf("Key %v not bound on the menu", key)
+		return nil
+	}
+	mv := g.CurrentView()
+	if err := handler(gocui.KeyArrowDown)(g, mv); err != nil {
+		t.Fatalf("Arrow down failed: %v", err)
+	}
+	if err := handler(gocui.KeyEnter)(g, mv); err != nil {
+		t.Fatalf("Enter failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if len(chosen) != 1 || chosen[0] != "paste b" {
+		t.Errorf("Unexpected actions: got %v, want [paste b]", chosen)
+	}
+	if viewExists(g, menuView) {
+		t.Errorf("Menu not deleted once closed")
+	}
+	if cur := g.CurrentView(); cur == nil || cur.Name() != "a" {
+		t.Errorf("Expected a to get the focus back, got %v", cur)
+	}
+}
@@ -0,0 +1,121 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+type modalState struct {
+	level *layoutLevel
+	w, h  int
+
+	// Where the modal's top left corner goes, if placed rather than
+	// centered.
+	x, y   int
+	placed bool
+
+	// The view focused when the modal was shown, focused again once it is
+	// closed, and whether the modal was given the focus yet.
+	prevFocus string
+	focused   bool
+}
+
+// ShowModal displays level over the layout, centered, w columns wide and h
+// lines high, or as much as fits. While it is displayed, the focus is kept
+// within it: FocusNext, FocusPrev and mouse focus only move between its views,
+// and the keys of the layout's own items, set with WithKeybinding, are unbound.
+// Its item marked WithInitialFocus, or else its first view, is focused once
+// rendered. Modals can be shown over each other, only the last one shown
+// having the focus.
+func (l *layoutLevel) ShowModal(g *gocui.Gui, level *layoutLevel, w, h int) {
+	l.showModal(g, &modalState{level: level, w: w, h: h})
+}
+
+func (l *layoutLevel) showModal(g *gocui.Gui, m *modalState) {
+	if v := g.CurrentView(); v != nil {
+		m.prevFocus = v.Name()
+	}
+	l.modals = append(l.modals, m)
+	l.changed()
+}
+
+// CloseModal removes the last modal shown, deleting its views on the next
+// render, when the view that had the focus before it was shown gets it back.
+func (l *layoutLevel) CloseModal() {
+	m := l.topModal()
+	if m == nil {
+		return
+	}
+
+	l.modals = l.modals[:len(l.modals)-1]
+	l.dropItems(m.level)
+	if below := l.topModal(); below != nil {
+		below.level.focusName = m.prevFocus
+	} else {
+		l.focusName = m.prevFocus
+	}
+	l.changed()
+}
+
+// Modal returns the last modal shown, or nil if there is none.
+func (l *layoutLevel) Modal() *layoutLevel {
+	if m := l.topModal(); m != nil {
+		return m.level
+	}
+	return nil
+}
+
+func (l *layoutLevel) topModal() *modalState {
+	if len(l.modals) == 0 {
+		return nil
+	}
+	return l.modals[len(l.modals)-1]
+}
+
+// layoutModals renders the modals over the layout, in the order they were
+// shown, raising their views above the rest.
+func (l *layoutLevel) layoutModals(g *gocui.Gui, x0, y0, x1, y1 int) error {
+	for _, m := range l.modals {
+		w, h := m.w, m.h
+		if w > x1-x0+1 {
+			w = x1 - x0 + 1
+		}
+		if h > y1-y0+1 {
+			h = y1 - y0 + 1
+		}
+		mx, my := x0+(x1-x0+1-w)/2, y0+(y1-y0+1-h)/2
+		if m.placed {
+			// Keep the modal on the screen, moving it back as needed.
+			mx, my = m.x, m.y
+			if mx+w-1 > x1 {
+				mx = x1 - w + 1
+			}
+			if my+h-1 > y1 {
+				my = y1 - h + 1
+			}
+		}
+		if err := m.level.LayoutRect(g, mx, my, mx+w-1, my+h-1); err != nil {
+			return err
+		}
+
+		for _, name := range m.level.viewNames() {
+			if _, err := g.SetViewOnTop(name); err != nil && err != gocui.ErrUnknownView {
+				return err
+			}
+		}
+	}
+
+	m := l.topModal()
+	if m == nil || m.focused {
+		return nil
+	}
+	m.focused = true
+	if v := g.CurrentView(); v != nil {
+		if item, err := m.level.findItem(v.Name()); err == nil && m.level.displayed(item) {
+			return nil
+		}
+	}
+	if items := m.level.focusOrder(); len(items) > 0 {
+		return m.level.focus(g, items[0])
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestModal(t *testing.T) {
+	g := newTestGui(t, false)
+	handler := func(*gocui.Gui, *gocui.View) error { return nil }
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithKeybinding('x', gocui.ModNone, handler)),
+		NewRatioItem(1, "b"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.SetCurrentView("b"); err != nil {
+		t.Fatalf("SetCurrentView failed: %v", err)
+	}
+
+	focused := func(want string) {
+		t.Helper()
+		if v := g.CurrentView(); v == nil || v.Name() != want {
+			t.Errorf("Expected %q to be focused, got %v", want, v)
+		}
+	}
+
+	dialog := NewLevel(LayoutVertical,
+		NewRatioItem(1, "message"),
+		NewFixedItem(3, "buttons", WithInner(NewLevel(LayoutHorizontal,
+			NewRatioItem(1, "ok"),
+			NewRatioItem(1, "cancel"),
+		))),
+	)
+	l.ShowModal(g, dialog, 40, 11)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a":       {0, 0, 39, 24},
+		"b":       {40, 0, 79, 24},
+		"message": {20, 7, 59, 14},
+		"ok":      {20, 15, 39, 17},
+		"cancel":  {40, 15, 59, 17},
+	})
+	focused("message")
+	for i, v := range g.Views()[:2] {
+		if v.Name() != []string{"a", "b"}[i] {
+			t.Errorf("Expected the modal above the layout, got %q at %d", v.Name(), i)
+		}
+	}
+	if len(l.keysBound) != 0 {
+		t.Errorf("Keys still bound under the modal: %v", l.keysBound)
+	}
+
+	// The focus cycles within the modal.
+	for _, want := range []string{"ok", "cancel", "message"} {
+		if err := l.FocusNext(g); err != nil {
+			t.Fatalf("FocusNext failed: %v", err)
+		}
+		focused(want)
+	}
+	v, err := g.View("a")
+	if err != nil {
+		t.Fatalf("View a not found: %v", err)
+	}
+	if err := l.mouseFocus(g, v); err != nil {
+		t.Fatalf("mouseFocus failed: %v", err)
+	}
+	focused("message")
+
+	// A modal over the modal.
+	if err := l.FocusNext(g); err != nil {
+		t.Fatalf("FocusNext failed: %v", err)
+	}
+	l.ShowModal(g, NewLevel(LayoutHorizontal, NewRatioItem(1, "confirm")), 20, 3)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	focused("confirm")
+	if got := l.Modal().items[0].name; got != "confirm" {
+		t.Errorf("Unexpected modal: got %q, want confirm", got)
+	}
+
+	l.CloseModal()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	focused("ok")
+	if viewExists(g, "confirm") {
+		t.Errorf("View of closed modal not deleted")
+	}
+
+	l.CloseModal()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	focused("b")
+	for _, name := range []string{"message", "ok", "cancel"} {
+		if viewExists(g, name) {
+			t.Errorf("View %q of closed modal not deleted", name)
+		}
+	}
+	if len(l.keysBound) != 1 {
+		t.Errorf("Keys not bound again after the modal: %v", l.keysBound)
+	}
+	if l.Modal() != nil {
+		t.Errorf("Unexpected modal left: %v", l.Modal())
+	}
+}
@@ -0,0 +1,54 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// captureView is the name of the view used to track the mouse while
+// something is being dragged.
+const captureView = "_layoutCapture"
+
+// gocui only reports the view under the mouse, and places that view's cursor
+// as close as its buffer allows to the mouse position. startCapture covers the
+// whole screen with an invisible view, filled with blanks, so that the
+// position of each following mouse event can be read back from its cursor.
+//
+// Pressing and hovering are indistinguishable in gocui, so a drag is started
+// by a click, onMove is called as the mouse moves, and onDrop is called on the
+// next click, after which the capture is removed.
+func startCapture(g *gocui.Gui, onMove, onDrop func(x, y int) error) error {
+	maxX, maxY := g.Size()
+	v, err := g.SetView(captureView, -1, -1, maxX, maxY, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Visible = false
+	v.Frame = false
+	v.Clear()
+	line := strings.Repeat(" ", maxX)
+	for y := 0; y < maxY; y++ {
+		v.WriteString(line + "\n")
+	}
+	if _, err := g.SetViewOnTop(captureView); err != nil {
+		return err
+	}
+
+	g.DeleteKeybindings(captureView)
+	if err := g.SetKeybinding(captureView, gocui.MouseRelease, gocui.ModNone,
+		func(g *gocui.Gui, v *gocui.View) error {
+			return onMove(v.Cursor())
+		}); err != nil {
+		return err
+	}
+	return g.SetKeybinding(captureView, gocui.MouseLeft, gocui.ModNone,
+		func(g *gocui.Gui, v *gocui.View) error {
+			x, y := v.Cursor()
+			g.DeleteKeybindings(captureView)
+			if err := g.DeleteView(captureView); err != nil {
+				return err
+			}
+			return onDrop(x, y)
+		})
+}
@@ -0,0 +1,129 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// FocusFollowsMouse focuses the view under the mouse as it moves over the
+// layout, or is clicked. The gui must have Mouse enabled. gocui reports the
+// topmost view at the mouse position, even if it is hidden, so the item is
+// found from the layout's own geometry instead. Views with their own mouse
+// bindings handle those events themselves, without the focus changing.
+func (l *layoutLevel) FocusFollowsMouse(g *gocui.Gui) error {
+	// gocui reports mouse moves as releases.
+	for _, key := range []gocui.Key{gocui.MouseRelease, gocui.MouseLeft} {
+		if err := g.SetKeybinding("", key, gocui.ModNone, l.mouseFocus); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mouseFocus focuses the item under the mouse, as reported by gocui in v.
+func (l *layoutLevel) mouseFocus(g *gocui.Gui, v *gocui.View) error {
+	if v == nil {
+		return nil
+	}
+	if m := l.topModal(); m != nil {
+		return m.level.mouseFocus(g, v)
+	}
+
+	item, err := l.findItem(v.Name())
+	if err != nil || item.inner != nil || !l.displayed(item) {
+		// The view isn't one the layout displays, such as the view of a
+		// hidden item. gocui placed its cursor within it, as close to the
+		// mouse as possible.
+		x0, y0, _, _ := v.Dimensions()
+		cx, cy := v.Cursor()
+		if item = l.itemAt(x0+1+cx, y0+1+cy); item == nil {
+			return nil
+		}
+	}
+
+	if !item.focusable() {
+		return nil
+	}
+	if cur := g.CurrentView(); cur != nil && cur.Name() == item.name {
+		return nil
+	}
+	return l.focus(g, item)
+}
+
+// itemAt returns the displayed view item at the given position, if any.
+func (l *layoutLevel) itemAt(x, y int) *layoutItem {
+	for i := len(l.floats) - 1; i >= 0; i-- {
+		item := l.floats[i]
+		r := item.rect
+		if !item.hidden && x >= r.X0 && x <= r.X1 && y >= r.Y0 && y <= r.Y1 {
+			return item
+		}
+	}
+
+	var found *layoutItem
+	l.tiledViews(func(item *layoutItem) {
+		r := item.rect
+		if found == nil && x >= r.X0 && x <= r.X1 && y >= r.Y0 && y <= r.Y1 {
+			found = item
+		}
+	})
+	return found
+}
+
+// EnableMouseFocus focuses the layout's views when they are clicked. The gui
+// must have Mouse enabled. Unlike FocusFollowsMouse, each view is bound on its
+// own, so this takes precedence over global mouse bindings. Views of items
+// added to the layout later are bound when it is next rendered.
+func (l *layoutLevel) EnableMouseFocus(g *gocui.Gui) error {
+	l.clickFocus = true
+	return l.bindClickFocus(g)
+}
+
+// bindClickFocus binds the views of the layout to focus them when clicked,
+// and unbinds those no longer in it.
+func (l *layoutLevel) bindClickFocus(g *gocui.Gui) error {
+	version := l.treeVersion()
+	if !l.clickFocus || (l.clickBound != nil && version == l.clickFocusVersion) {
+		return nil
+	}
+	l.clickFocusVersion = version
+
+	names := make(map[string]bool)
+	l.viewItems(func(item *layoutItem) {
+		names[item.name] = true
+	})
+
+	if l.clickBound == nil {
+		l.clickBound = make(map[string]bool)
+	}
+	for name := range l.clickBound {
+		if !names[name] {
+			g.DeleteKeybinding(name, gocui.MouseLeft, gocui.ModNone)
+			delete(l.clickBound, name)
+		}
+	}
+	for name := range names {
+		if l.clickBound[name] {
+			continue
+		}
+		if err := g.SetKeybinding(name, gocui.MouseLeft, gocui.ModNone, l.mouseFocus); err != nil {
+			return err
+		}
+		l.clickBound[name] = true
+	}
+	return nil
+}
+
+// viewItems calls f for every item of the layout displayed as a view, whether
+// or not it is hidden.
+func (l *layoutLevel) viewItems(f func(*layoutItem)) {
+	for _, item := range l.items {
+		if item.inner != nil {
+			item.inner.viewItems(f)
+		} else if item.manager == nil {
+			f(item)
+		}
+	}
+	if l.takeover != nil {
+		l.takeover.viewItems(f)
+	}
+}
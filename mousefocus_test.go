@@ -0,0 +1,90 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFocusFollowsMouse(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "hidden", Hidden()),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.FocusFollowsMouse(g); err != nil {
+		t.Fatalf("FocusFollowsMouse failed: %v", err)
+	}
+
+	tests := []struct {
+		view   string
+		cx, cy int
+		want   string
+	}{
+		{"b", 1, 1, "b"},
+		{"a", 0, 0, "a"},
+		// The hidden view covers the level, below the others.
+		{"hidden", 45, 3, "b"},
+		{"hidden", 2, 3, "a"},
+	}
+	for _, tc := range tests {
+		v, err := g.View(tc.view)
+		if err != nil {
+			t.Fatalf("View %q not found: %v", tc.view, err)
+		}
+		if err := v.SetCursorUnrestricted(tc.cx, tc.cy); err != nil {
+			t.Fatalf("SetCursor failed: %v", err)
+		}
+		if err := l.mouseFocus(g, v); err != nil {
+			t.Fatalf("mouseFocus failed: %v", err)
+		}
+		if cur := g.CurrentView(); cur == nil || cur.Name() != tc.want {
+			t.Errorf("Mouse over %q at %d,%d: expected %q focused, got %v", tc.view, tc.cx, tc.cy, tc.want, cur)
+		}
+	}
+}
+
+func TestEnableMouseFocus(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+		))),
+	)
+	if err := l.EnableMouseFocus(g); err != nil {
+		t.Fatalf("EnableMouseFocus failed: %v", err)
+	}
+	want := map[string]bool{"a": true, "b": true}
+	if !reflect.DeepEqual(l.clickBound, want) {
+		t.Errorf("Unexpected bindings: want %v, got %v", want, l.clickBound)
+	}
+
+	if err := l.AddItem("col", -1, NewRatioItem(1, "c")); err != nil {
+		t.Fatalf("AddItem failed: %v", err)
+	}
+	if err := l.RemoveItem("a"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	want = map[string]bool{"b": true, "c": true}
+	if !reflect.DeepEqual(l.clickBound, want) {
+		t.Errorf("Unexpected bindings: want %v, got %v", want, l.clickBound)
+	}
+
+	v, err := g.View("c")
+	if err != nil {
+		t.Fatalf("View c not found: %v", err)
+	}
+	if err := l.mouseFocus(g, v); err != nil {
+		t.Fatalf("mouseFocus failed: %v", err)
+	}
+	if cur := g.CurrentView(); cur != v {
+		t.Errorf("Expected c to be focused, got %v", cur)
+	}
+}
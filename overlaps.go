@@ -0,0 +1,47 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// overlaps returns, for each visible item, which of its edges are shared with
+// another view, so that gocui draws shared borders as a single line. Items
+// share the edges between them, and those of the level's own shared edges
+// that they lie on.
+func (l *layoutLevel) overlaps(places []placement, edges byte) []byte {
+	var before, after, sides byte = gocui.LEFT, gocui.RIGHT, gocui.TOP | gocui.BOTTOM
+	if l.direction == LayoutVertical {
+		before, after, sides = gocui.TOP, gocui.BOTTOM, gocui.LEFT|gocui.RIGHT
+	}
+
+	first, last := -1, -1
+	for idx := range places {
+		if places[idx].hidden {
+			continue
+		}
+		if first < 0 {
+			first = idx
+		}
+		last = idx
+	}
+
+	overlaps := make([]byte, len(places))
+	for idx := range places {
+		if places[idx].hidden {
+			continue
+		}
+		o := edges & sides
+		if idx == first {
+			o |= edges & before
+		} else {
+			o |= before
+		}
+		if idx == last {
+			o |= edges & after
+		} else {
+			o |= after
+		}
+		overlaps[idx] = o
+	}
+	return overlaps
+}
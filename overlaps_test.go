@@ -0,0 +1,65 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestOverlaps(t *testing.T) {
+	l := NewLevel(LayoutVertical,
+		NewFixedItem(3, "header"),
+		NewRatioItem(1, "body", WithInner(NewLevel(LayoutHorizontal,
+			NewFixedItem(20, "sidebar"),
+			NewRatioItem(1, "main"),
+			NewRatioItem(1, "hidden", Hidden()),
+		))),
+		NewFixedItem(3, "footer"),
+	)
+
+	tests := []struct {
+		desc     string
+		overlaps bool
+		want     map[string]byte
+	}{
+		{
+			desc:     "with overlaps",
+			overlaps: true,
+			want: map[string]byte{
+				"header":  gocui.BOTTOM,
+				"sidebar": gocui.TOP | gocui.BOTTOM | gocui.RIGHT,
+				"main":    gocui.TOP | gocui.BOTTOM | gocui.LEFT,
+				"footer":  gocui.TOP,
+				"hidden":  0,
+			},
+		},
+		{
+			desc:     "without overlaps",
+			overlaps: false,
+			want: map[string]byte{
+				"header":  0,
+				"sidebar": 0,
+				"main":    0,
+				"footer":  0,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := newTestGui(t, tc.overlaps)
+			if err := l.Layout(g); err != nil {
+				t.Fatalf("Layout failed: %v", err)
+			}
+			for name, want := range tc.want {
+				v, err := g.View(name)
+				if err != nil {
+					t.Fatalf("View %q not found: %v", name, err)
+				}
+				if v.Overlaps != want {
+					t.Errorf("Unexpected overlaps for %q: want %04b, got %04b", name, want, v.Overlaps)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package layout
+
+// The items holding the levels of the presets are named after the items they
+// hold, so that several presets can be used in the same layout.
+
+// IDELayout is the classic sidebar, editor and terminal arrangement, above a
+// status bar. It can be used directly as a gui's manager.
+type IDELayout struct {
+	*layoutLevel
+	sidebar  string
+	terminal string
+}
+
+// NewIDE creates a layout with a sidebar on the left, an editor with a
+// terminal below it on the right, and a status bar across the bottom.
+func NewIDE(sidebar, editor, terminal, statusbar string) *IDELayout {
+	work := NewLevel(LayoutVertical,
+		NewRatioItem(3, editor),
+		NewRatioItem(1, terminal),
+	)
+	body := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, sidebar),
+		NewRatioItem(4, editor+"~work", WithInner(work)),
+	)
+
+	return &IDELayout{
+		layoutLevel: NewLevel(LayoutVertical,
+			NewRatioItem(1, editor+"~body", WithInner(body)),
+			NewFixedItem(3, statusbar),
+		),
+		sidebar:  sidebar,
+		terminal: terminal,
+	}
+}
+
+// ToggleSidebar shows or hides the sidebar.
+func (i *IDELayout) ToggleSidebar() error {
+	return i.ToggleItem(i.sidebar)
+}
+
+// ToggleTerminal shows or hides the terminal.
+func (i *IDELayout) ToggleTerminal() error {
+	return i.ToggleItem(i.terminal)
+}
+
+// ChatLayout is a chat application arrangement: a roster column next to the
+// messages, with an input line below them.
+type ChatLayout struct {
+	*layoutLevel
+	roster string
+}
+
+// NewChat creates a layout with a collapsible roster on the left, and the
+// messages above a fixed height input row on the right.
+func NewChat(roster, messages, input string) *ChatLayout {
+	main := NewLevel(LayoutVertical,
+		NewRatioItem(1, messages),
+		NewFixedItem(3, input),
+	)
+
+	return &ChatLayout{
+		layoutLevel: NewLevel(LayoutHorizontal,
+			NewRatioItem(1, roster),
+			NewRatioItem(4, messages+"~main", WithInner(main)),
+		),
+		roster: roster,
+	}
+}
+
+// ToggleRoster shows or hides the roster.
+func (c *ChatLayout) ToggleRoster() error {
+	return c.ToggleItem(c.roster)
+}
+
+// NewDashboard arranges the named views in a grid with the given number of
+// columns, filling each row before starting the next. The views of an
+// incomplete last row share its full width.
+func NewDashboard(cols int, names ...string) *layoutLevel {
+	if cols <= 0 {
+		panic("invalid column count when creating dashboard")
+	}
+
+	var rows []*layoutItem
+	for start := 0; start < len(names); start += cols {
+		end := start + cols
+		if end > len(names) {
+			end = len(names)
+		}
+
+		var items []*layoutItem
+		for _, name := range names[start:end] {
+			items = append(items, NewRatioItem(1, name))
+		}
+		rows = append(rows, NewRatioItem(1, names[start]+"~row",
+			WithInner(NewLevel(LayoutHorizontal, items...))))
+	}
+
+	return NewLevel(LayoutVertical, rows...)
+}
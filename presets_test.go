@@ -0,0 +1,89 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestIDE(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewIDE("files", "editor", "shell", "status")
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"files":  {0, 0, 15, 21},
+		"editor": {16, 0, 79, 14},
+		"shell":  {16, 15, 79, 21},
+		"status": {0, 22, 79, 24},
+	})
+
+	if err := l.ToggleSidebar(); err != nil {
+		t.Fatalf("ToggleSidebar failed: %v", err)
+	}
+	if err := l.ToggleTerminal(); err != nil {
+		t.Fatalf("ToggleTerminal failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"editor": {0, 0, 79, 21},
+		"status": {0, 22, 79, 24},
+	})
+}
+
+func TestChat(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewChat("roster", "messages", "input")
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"roster":   {0, 0, 15, 24},
+		"messages": {16, 0, 79, 21},
+		"input":    {16, 22, 79, 24},
+	})
+
+	if err := l.ToggleRoster(); err != nil {
+		t.Fatalf("ToggleRoster failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"messages": {0, 0, 79, 21},
+		"input":    {0, 22, 79, 24},
+	})
+}
+
+func TestDashboard(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewDashboard(2, "cpu", "mem", "disk", "net", "load")
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"cpu":  {0, 0, 39, 7},
+		"mem":  {40, 0, 79, 7},
+		"disk": {0, 8, 39, 15},
+		"net":  {40, 8, 79, 15},
+		"load": {0, 16, 79, 24},
+	})
+}
+
+func TestPresetsTogether(t *testing.T) {
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "left", WithInner(NewDashboard(2, "cpu", "mem", "disk"))),
+		NewRatioItem(1, "right", WithInner(NewDashboard(1, "net", "load"))),
+		NewRatioItem(1, "chats", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "work", WithInner(NewChat("team", "teamMsgs", "teamInput").layoutLevel)),
+			NewRatioItem(1, "home", WithInner(NewChat("family", "familyMsgs", "familyInput").layoutLevel)),
+		))),
+	)
+	if err := l.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// Prune deletes the views created by the layout that no longer belong to any
+// of its items, such as after items were removed or renamed. Such views are
+// usually deleted on the next render, Prune does so immediately, including any
+// that were missed.
+func (l *layoutLevel) Prune(g *gocui.Gui) error {
+	return l.prune(g, l.keep())
+}
+
+// track records the views of the layout, so they can later be pruned. The
+// views only change with the tree, so there's nothing new to record if it
+// hasn't changed since the last time.
+func (l *layoutLevel) track(version uint64) {
+	if l.created == nil {
+		l.created = make(map[string]bool)
+	} else if version == l.tracked {
+		return
+	}
+	l.tracked = version
+	for _, name := range l.viewNames() {
+		l.created[name] = true
+	}
+}
+
+// keep returns the names of the views currently in the layout.
+func (l *layoutLevel) keep() map[string]bool {
+	keep := make(map[string]bool)
+	for _, name := range l.viewNames() {
+		keep[name] = true
+	}
+	return keep
+}
+
+// prune deletes the views created by the layout, other than those to keep.
+func (l *layoutLevel) prune(g *gocui.Gui, keep map[string]bool) error {
+	for name := range l.created {
+		if keep[name] {
+			continue
+		}
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		delete(l.created, name)
+	}
+
+	return nil
+}
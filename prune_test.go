@@ -0,0 +1,81 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func viewExists(g *gocui.Gui, name string) bool {
+	_, err := g.View(name)
+	return err == nil
+}
+
+func TestPrune(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "c"),
+			NewRatioItem(1, "d"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.SetView("other", 0, 0, 5, 5, 0); err != nil && err != gocui.ErrUnknownView {
+		t.Fatalf("SetView failed: %v", err)
+	}
+
+	if err := l.RemoveItem("b"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.RenameItem("a", "e"); err != nil {
+		t.Fatalf("RenameItem failed: %v", err)
+	}
+	if err := l.Prune(g); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"a":     false,
+		"c":     false,
+		"d":     false,
+		"other": true,
+	} {
+		if got := viewExists(g, name); got != want {
+			t.Errorf("View %q exists: want %v, got %v", name, want, got)
+		}
+	}
+}
+
+func TestWorkspacesPrune(t *testing.T) {
+	g := newTestGui(t, false)
+	w := NewWorkspaces()
+	if err := w.Add("one", NewLevel(LayoutHorizontal, NewRatioItem(1, "a"), NewRatioItem(1, "b"))); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := w.Add("two", NewLevel(LayoutHorizontal, NewRatioItem(1, "b"), NewRatioItem(1, "c"))); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := w.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := w.Switch("two"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	if err := w.Prune(g); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"a": false,
+		"b": true,
+		"c": false,
+	} {
+		if got := viewExists(g, name); got != want {
+			t.Errorf("View %q exists: want %v, got %v", name, want, got)
+		}
+	}
+}
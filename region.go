@@ -0,0 +1,30 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// Region returns a manager that renders m within the given rectangle of the
+// screen, rather than over all of it. Negative coordinates are counted back
+// from the right or bottom edge, so that -1 is the last column or row.
+func Region(m RectManager, x0, y0, x1, y1 int) gocui.Manager {
+	return gocui.ManagerFunc(func(g *gocui.Gui) error {
+		maxX, maxY := g.Size()
+		rx0, rx1 := fromEdge(x0, maxX), fromEdge(x1, maxX)
+		ry0, ry1 := fromEdge(y0, maxY), fromEdge(y1, maxY)
+		return m.LayoutRect(g, rx0, ry0, rx1, ry1)
+	})
+}
+
+// LayoutIn returns a manager that renders the layout within the given
+// rectangle of the screen. See Region for how coordinates are interpreted.
+func (l *layoutLevel) LayoutIn(x0, y0, x1, y1 int) gocui.Manager {
+	return Region(l, x0, y0, x1, y1)
+}
+
+func fromEdge(pos, max int) int {
+	if pos < 0 {
+		return max + pos
+	}
+	return pos
+}
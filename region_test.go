@@ -0,0 +1,29 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestLayoutIn(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "left"),
+		NewRatioItem(1, "right"),
+	)
+
+	if err := l.LayoutIn(0, 0, -1, -3).Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"left":  {0, 0, 39, 22},
+		"right": {40, 0, 79, 22},
+	})
+
+	if err := Region(l, 10, 5, 49, 14).Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"left":  {10, 5, 29, 14},
+		"right": {30, 5, 49, 14},
+	})
+}
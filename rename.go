@@ -0,0 +1,104 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// RenameItem changes the name of an item. Since gocui identifies views by
+// name, the item's view is recreated under the new name on the next render,
+// with the contents and settings of the old one, which is then deleted. The
+// item's create function is not called again. A floating item's handles move
+// along with it. Keybindings set on the old view name are not carried over.
+func (l *layoutLevel) RenameItem(oldName, newName string) error {
+	i, err := l.findItem(oldName)
+	if err != nil {
+		return err
+	}
+
+	if oldName == newName {
+		return nil
+	}
+	if _, err := l.findItem(newName); err == nil {
+		return &ItemError{Item: newName, Err: DuplicateName}
+	}
+
+	l.record()
+	i.rename(newName)
+
+	return nil
+}
+
+// rename changes the item's name, keeping track of the view to migrate.
+func (l *layoutItem) rename(name string) {
+	if l.inner == nil && l.manager == nil {
+		if l.renamedFrom == "" {
+			l.renamedFrom = l.name
+		} else if l.renamedFrom == name {
+			l.renamedFrom = ""
+		}
+	}
+	l.name = name
+}
+
+// migrateView moves the view of a renamed item to its new name.
+func (l *layoutItem) migrateView(g *gocui.Gui) error {
+	if l.renamedFrom == "" {
+		return nil
+	}
+
+	from := l.renamedFrom
+	l.renamedFrom = ""
+
+	// A floating item's handles are created again under the new name.
+	if l.float != nil {
+		if err := deleteHandles(g, from); err != nil {
+			return err
+		}
+	}
+
+	old, err := g.View(from)
+	if err != nil {
+		return nil
+	}
+
+	x0, y0, x1, y1 := old.Dimensions()
+	v, err := g.SetView(l.name, x0, y0, x1, y1, old.Overlaps)
+	if err == gocui.ErrUnknownView {
+		copyView(v, old)
+		if g.CurrentView() == old {
+			g.SetCurrentView(l.name)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	return g.DeleteView(from)
+}
+
+// copyView copies the contents and settings of one view to another.
+func copyView(dst, src *gocui.View) {
+	dst.Visible = src.Visible
+	dst.Frame = src.Frame
+	dst.FrameColor = src.FrameColor
+	dst.FrameRunes = src.FrameRunes
+	dst.Title = src.Title
+	dst.TitleColor = src.TitleColor
+	dst.Subtitle = src.Subtitle
+	dst.FgColor, dst.BgColor = src.FgColor, src.BgColor
+	dst.SelFgColor, dst.SelBgColor = src.SelFgColor, src.SelBgColor
+	dst.Editable = src.Editable
+	dst.Editor = src.Editor
+	dst.Overwrite = src.Overwrite
+	dst.Highlight = src.Highlight
+	dst.Wrap = src.Wrap
+	dst.Autoscroll = src.Autoscroll
+	dst.Mask = src.Mask
+	dst.KeybindOnEdit = src.KeybindOnEdit
+
+	dst.Clear()
+	dst.WriteString(src.Buffer())
+	ox, oy := src.Origin()
+	dst.SetOrigin(ox, oy)
+	cx, cy := src.Cursor()
+	dst.SetCursor(cx, cy)
+}
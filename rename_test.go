@@ -0,0 +1,94 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestRenameItem(t *testing.T) {
+	g := newTestGui(t, false)
+	created := 0
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "chan1", WithCreate(func(v *gocui.View) error {
+			created++
+			v.Title = "channel"
+			v.WriteString("hello")
+			return nil
+		})),
+		NewRatioItem(1, "users"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	g.SetCurrentView("chan1")
+
+	if err := l.RenameItem("chan1", "general"); err != nil {
+		t.Fatalf("RenameItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	checkViews(t, g, map[string]size{
+		"general": {0, 0, 39, 24},
+		"users":   {40, 0, 79, 24},
+	})
+	if _, err := g.View("chan1"); err != gocui.ErrUnknownView {
+		t.Errorf("Old view was not deleted")
+	}
+	v, err := g.View("general")
+	if err != nil {
+		t.Fatalf("Can't get renamed view: %v", err)
+	}
+	if v.Title != "channel" || v.Buffer() != "hello" {
+		t.Errorf("View not migrated: title %q, buffer %q", v.Title, v.Buffer())
+	}
+	if g.CurrentView() != v {
+		t.Errorf("Renamed view did not keep the focus")
+	}
+	if created != 1 {
+		t.Errorf("Create function called %d times, want 1", created)
+	}
+
+	if err := l.RenameItem("general", "users"); !errors.Is(err, DuplicateName) {
+		t.Errorf("Unexpected error for duplicate name: got %v, want %v", err, DuplicateName)
+	}
+}
+
+func TestRenameFloatingItem(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "chan1"),
+		NewRatioItem(1, "users"),
+	)
+	if err := l.FloatItem("chan1", 20, 10); err != nil {
+		t.Fatalf("FloatItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.RenameItem("chan1", "general"); err != nil {
+		t.Fatalf("RenameItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	for _, h := range floatHandles {
+		if _, err := g.View("chan1" + h.suffix); err != gocui.ErrUnknownView {
+			t.Errorf("Old handle %q was not deleted", "chan1"+h.suffix)
+		}
+		if err := g.DeleteKeybinding("chan1"+h.suffix, gocui.MouseLeft, gocui.ModNone); err == nil {
+			t.Errorf("Old handle %q kept its keybinding", "chan1"+h.suffix)
+		}
+		if _, err := g.View("general" + h.suffix); err != nil {
+			t.Errorf("No handle %q: %v", "general"+h.suffix, err)
+		}
+	}
+	if err := g.DeleteKeybinding("general~move", gocui.MouseLeft, gocui.ModNone); err != nil {
+		t.Errorf("New handle has no keybinding: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// Replace installs a new tree in place of the layout's current contents, so
+// that the layout can remain the gui's manager. Views of the old tree that are
+// not part of the new one are deleted, while views in both are kept along with
+// their contents. The layout keeps its settings and handlers, while its name,
+// items, rounding, zoomed and floating items and modals are those of the new
+// root, which should not be used on its own afterwards. The history of changes
+// is cleared, and the new tree is recorded for Reset when it is first rendered.
+func (l *layoutLevel) Replace(g *gocui.Gui, newRoot *layoutLevel) error {
+	if newRoot == nil {
+		return &ItemError{Item: l.name, Err: InvalidValues}
+	}
+
+	l.dropItems(l)
+	keep := newRoot.keep()
+	for _, name := range l.stale {
+		if keep[name] {
+			continue
+		}
+		if err := deleteView(g, name, l.destroys[name], l.deferCallbacks); err != nil {
+			return err
+		}
+	}
+	l.stale = nil
+	l.destroys = nil
+
+	settings := l.layoutSettings
+	*l = *newRoot
+	l.layoutSettings = settings
+	l.undo = nil
+	l.redo = nil
+	l.snapshot = nil
+	l.changed()
+
+	return nil
+}
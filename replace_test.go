@@ -0,0 +1,116 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestReplace(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	v, err := g.View("b")
+	if err != nil {
+		t.Fatalf("View failed: %v", err)
+	}
+	v.WriteString("hello")
+
+	created := false
+	err = l.Replace(g, NewLevel(LayoutVertical,
+		NewFixedItem(5, "b"),
+		NewRatioItem(1, "c", WithCreate(func(*gocui.View) error {
+			created = true
+			return nil
+		})),
+	))
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if viewExists(g, "a") {
+		t.Errorf("View a was not deleted")
+	}
+	if err := l.Undo(); !errors.Is(err, NoHistory) {
+		t.Errorf("Unexpected error from Undo: got %v, want %v", err, NoHistory)
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {0, 0, 79, 4},
+		"c": {0, 5, 79, 24},
+	})
+	if !created {
+		t.Errorf("View c was not created")
+	}
+	v, err = g.View("b")
+	if err != nil {
+		t.Fatalf("View failed: %v", err)
+	}
+	if got := v.Buffer(); got != "hello" {
+		t.Errorf("Unexpected contents of b: want %q, got %q", "hello", got)
+	}
+
+	if err := l.Replace(g, nil); !errors.Is(err, InvalidValues) {
+		t.Errorf("Unexpected error for a nil tree: got %v, want %v", err, InvalidValues)
+	}
+}
+
+func TestReplaceResetsState(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewNamedLevel("old", LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "c"),
+	)
+	if err := l.SetRounding("", RoundDown, RemainderFirst); err != nil {
+		t.Fatalf("SetRounding failed: %v", err)
+	}
+	if err := l.Zoom("a"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	if err := l.FloatItem("b", 20, 10); err != nil {
+		t.Fatalf("FloatItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	err := l.Replace(g, NewNamedLevel("new", LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b"),
+		NewRatioItem(1, "c"),
+	))
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if z := l.Zoomed(); z != "" {
+		t.Errorf("Unexpected zoomed item: %q", z)
+	}
+	if f := l.Floating(); len(f) != 0 {
+		t.Errorf("Unexpected floating items: %v", f)
+	}
+	var ie *ItemError
+	if err := l.Replace(g, nil); !errors.As(err, &ie) || ie.Item != "new" {
+		t.Errorf("Unexpected name of the layout: %v", err)
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 25, 24},
+		"b": {26, 0, 51, 24},
+		"c": {52, 0, 79, 24},
+	})
+	if viewExists(g, "b~move") {
+		t.Errorf("Handle of the floating item was not deleted")
+	}
+}
@@ -0,0 +1,104 @@
+package layout
+
+// Rounding selects how the space for each unit of weight is rounded, when
+// dividing a level between its ratio items.
+type Rounding int
+
+// Remainder selects which items are adjusted, when the space given to ratio
+// items doesn't add up to the space available.
+type Remainder int
+
+const (
+	// RoundDown rounds the space per unit down, leaving some space over.
+	RoundDown Rounding = iota
+	// RoundNearest rounds the space per unit to the nearest whole number.
+	RoundNearest
+	// RoundUp rounds the space per unit up, taking the extra space from
+	// some of the items.
+	RoundUp
+)
+
+const (
+	// RemainderLast gives any space left over to the last item, or takes
+	// any extra space from the last ratio items.
+	RemainderLast Remainder = iota
+	// RemainderFirst does the same for the first ratio items.
+	RemainderFirst
+	// RemainderSpread spreads the difference a line or column at a time
+	// over all the ratio items, starting with the first.
+	RemainderSpread
+)
+
+// SetRounding sets how the level held by the named item, or the layout itself
+// if levelName is empty, is divided between its ratio items. By default, the
+// space per unit of weight is rounded down, and the last item is given what is
+// left over.
+func (l *layoutLevel) SetRounding(levelName string, rounding Rounding, remainder Remainder) error {
+	level, err := l.levelOf(levelName)
+	if err != nil {
+		return err
+	}
+
+	level.changed()
+	level.rounding = rounding
+	level.remainder = remainder
+
+	return nil
+}
+
+// unit returns the space given to each unit of weight.
+func (r Rounding) unit(length, segments int) int {
+	switch r {
+	case RoundNearest:
+		return (2*length + segments) / (2 * segments)
+	case RoundUp:
+		return (length + segments - 1) / segments
+	}
+	return length / segments
+}
+
+// attribute adds left, which may be negative, to the sizes of the ratio items
+// at idx. No item is shrunk below a single line or column. It reports false
+// if there wasn't enough space to do so.
+func (r Remainder) attribute(sizes, idx []int, left int) bool {
+	step := 1
+	if left < 0 {
+		step = -1
+	}
+
+	order := idx
+	if r == RemainderLast {
+		order = make([]int, len(idx))
+		for i := range idx {
+			order[i] = idx[len(idx)-1-i]
+		}
+	}
+
+	for left != 0 {
+		changed := false
+		for _, i := range order {
+			if left == 0 {
+				break
+			}
+			if sizes[i]+step < 1 {
+				continue
+			}
+			n := step
+			if r != RemainderSpread {
+				// Take as much as possible from this item.
+				n = left
+				if sizes[i]+n < 1 {
+					n = 1 - sizes[i]
+				}
+			}
+			sizes[i] += n
+			left -= n
+			changed = true
+		}
+		if !changed {
+			return false
+		}
+	}
+
+	return true
+}
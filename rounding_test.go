@@ -0,0 +1,53 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetRounding(t *testing.T) {
+	tests := []struct {
+		desc      string
+		rounding  Rounding
+		remainder Remainder
+		want      []int
+	}{
+		{"default", RoundDown, RemainderLast, []int{26, 26, 28}},
+		{"down, first", RoundDown, RemainderFirst, []int{28, 26, 26}},
+		{"down, spread", RoundDown, RemainderSpread, []int{27, 27, 26}},
+		{"nearest, last", RoundNearest, RemainderLast, []int{27, 27, 26}},
+		{"up, spread", RoundUp, RemainderSpread, []int{26, 27, 27}},
+		{"up, first", RoundUp, RemainderFirst, []int{26, 27, 27}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "a"),
+				NewRatioItem(1, "b"),
+				NewRatioItem(1, "c"),
+			)
+			if err := l.SetRounding("", tc.rounding, tc.remainder); err != nil {
+				t.Fatalf("SetRounding failed: %v", err)
+			}
+			rects, err := l.Compute(80, 25)
+			if err != nil {
+				t.Fatalf("Compute failed: %v", err)
+			}
+			for i, name := range []string{"a", "b", "c"} {
+				r := rects[name]
+				if got := r.X1 - r.X0 + 1; got != tc.want[i] {
+					t.Errorf("Unexpected width for %s: want %d, got %d", name, tc.want[i], got)
+				}
+			}
+		})
+	}
+
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical, NewRatioItem(1, "c")))),
+	)
+	if err := l.SetRounding("c", RoundUp, RemainderFirst); !errors.Is(err, NotContainer) {
+		t.Errorf("Unexpected error for an item without a level: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package layout
+
+import (
+	"fmt"
+)
+
+// NotRouter is an error returned when Navigate is called on an item that was
+// not created with WithRoutes.
+var NotRouter = fmt.Errorf("Item is not a router")
+
+type routerState struct {
+	routes  map[string]*layoutLevel
+	current string
+}
+
+// WithRoutes turns an item into a router: a region that renders one of
+// several named layouts, chosen with Navigate. The route named by initial is
+// displayed first.
+func WithRoutes(routes map[string]*layoutLevel, initial string) layoutItemOption {
+	return func(l *layoutItem) {
+		l.router = &routerState{routes: routes, current: initial}
+		l.inner = routes[initial]
+	}
+}
+
+// Navigate finds the named router within the layout (or sublayouts), and
+// switches it to display the named route. Views of the previous route are
+// deleted on the next layout.
+func (l *layoutLevel) Navigate(regionName, routeName string) error {
+	i, err := l.findItem(regionName)
+	if err != nil {
+		return err
+	}
+
+	if i.router == nil {
+		return l.itemError(regionName, NotRouter)
+	}
+
+	next, ok := i.router.routes[routeName]
+	if !ok {
+		path, _ := l.PathTo(regionName)
+		return &ItemError{Item: routeName, Path: path, Err: NotFound}
+	}
+
+	if i.inner != nil && i.inner != next {
+		l.dropItems(i.inner)
+	}
+	l.changed()
+	i.router.current = routeName
+	i.inner = next
+
+	return nil
+}
+
+// Route returns the name of the route currently displayed by the named
+// router.
+func (l *layoutLevel) Route(regionName string) (string, error) {
+	i, err := l.findItem(regionName)
+	if err != nil {
+		return "", err
+	}
+
+	if i.router == nil {
+		return "", l.itemError(regionName, NotRouter)
+	}
+
+	return i.router.current, nil
+}
@@ -0,0 +1,60 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestRouter(t *testing.T) {
+	g := newTestGui(t, false)
+	routes := map[string]*layoutLevel{
+		"inbox": NewLevel(LayoutVertical,
+			NewRatioItem(1, "list"),
+			NewRatioItem(1, "preview"),
+		),
+		"settings": NewLevel(LayoutVertical,
+			NewRatioItem(1, "settings"),
+		),
+	}
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(20, "menu"),
+		NewRatioItem(1, "main", WithRoutes(routes, "inbox")),
+	)
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"menu":    {0, 0, 19, 24},
+		"list":    {20, 0, 79, 11},
+		"preview": {20, 12, 79, 24},
+	})
+
+	if err := l.Navigate("main", "settings"); err != nil {
+		t.Fatalf("Navigate failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"menu":     {0, 0, 19, 24},
+		"settings": {20, 0, 79, 24},
+	})
+	for _, name := range []string{"list", "preview"} {
+		if _, err := g.View(name); err != gocui.ErrUnknownView {
+			t.Errorf("View %q of the previous route was not deleted", name)
+		}
+	}
+
+	if got, err := l.Route("main"); err != nil || got != "settings" {
+		t.Errorf("Unexpected route: got %q, %v, want %q", got, err, "settings")
+	}
+	if err := l.Navigate("main", "missing"); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for missing route: got %v, want %v", err, NotFound)
+	}
+	if err := l.Navigate("menu", "inbox"); !errors.Is(err, NotRouter) {
+		t.Errorf("Unexpected error for non-router: got %v, want %v", err, NotRouter)
+	}
+}
@@ -0,0 +1,186 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// NotSplitter is an error returned when a splitter method is called on a
+// level that was not created with NewSplitter.
+var NotSplitter = fmt.Errorf("Level is not a splitter")
+
+type splitState struct {
+	initial int
+	percent int
+	drag    bool
+	sash    string
+}
+
+// NewSplitter creates a level holding exactly two items, dividing the space
+// between them by percentage, ignoring the sizes the items were created with.
+// The split can be changed with SetSplit, or by dragging the border between
+// the items once EnableDrag was called.
+func NewSplitter(direction LayoutDirection, percent int, first, second *layoutItem) *layoutLevel {
+	if percent <= 0 || percent >= 100 {
+		panic("invalid percent when creating splitter")
+	}
+
+	l := NewLevel(direction, first, second)
+	l.splitter = &splitState{
+		initial: percent,
+		percent: percent,
+		sash:    first.name + "~sash",
+	}
+
+	return l
+}
+
+// sizeOf gives the first item its share of the length as a fixed size, and
+// the rest to the second item. If either is hidden, the other gets everything.
+func (s *splitState) sizeOf(l *layoutLevel, idx, length int) (ratio, fixed int) {
+	if idx == 1 || l.items[0].isHidden() || l.items[1].isHidden() {
+		return 1, 0
+	}
+
+	fixed = length * s.percent / 100
+	if fixed < 1 {
+		fixed = 1
+	}
+	return 0, fixed
+}
+
+// Split returns the percentage of the space currently given to the first
+// item of a splitter.
+func (l *layoutLevel) Split() (int, error) {
+	if l.splitter == nil {
+		return 0, NotSplitter
+	}
+
+	return l.splitter.percent, nil
+}
+
+// SetSplit changes the percentage of the space given to the first item of a
+// splitter. The second item gets the rest.
+func (l *layoutLevel) SetSplit(percent int) error {
+	if l.splitter == nil {
+		return NotSplitter
+	}
+
+	if percent <= 0 || percent >= 100 {
+		return &ItemError{Item: l.items[0].name, Err: fmt.Errorf("%w: percent %d", InvalidValues, percent)}
+	}
+
+	l.record()
+	l.splitter.percent = percent
+
+	return nil
+}
+
+// ResetSplit restores the percentage the splitter was created with.
+func (l *layoutLevel) ResetSplit() error {
+	if l.splitter == nil {
+		return NotSplitter
+	}
+
+	l.record()
+	l.splitter.percent = l.splitter.initial
+
+	return nil
+}
+
+// EnableDrag allows the split to be changed with the mouse: clicking on the
+// border between the items picks it up, and it follows the mouse until the
+// next click. Double-clicking the border splits the space evenly, and the
+// mouse wheel moves it a step at a time. The gui must have Mouse enabled.
+func (l *layoutLevel) EnableDrag(g *gocui.Gui) error {
+	if l.splitter == nil {
+		return NotSplitter
+	}
+
+	l.splitter.drag = true
+	l.changed()
+	for key, delta := range map[gocui.Key]int{gocui.MouseWheelUp: -1, gocui.MouseWheelDown: 1} {
+		delta := delta
+		if err := g.SetKeybinding(l.splitter.sash, key, gocui.ModNone,
+			func(g *gocui.Gui, v *gocui.View) error {
+				return l.nudgeSplit(delta)
+			}); err != nil {
+			return err
+		}
+	}
+	return g.SetKeybinding(l.splitter.sash, gocui.MouseLeft, gocui.ModNone,
+		func(g *gocui.Gui, v *gocui.View) error {
+			l.record()
+			return startCapture(g, l.dragSplit, doubleClicked(l.dragSplit, func() error {
+				l.equalize()
+				return nil
+			}))
+		})
+}
+
+// nudgeSplit moves the border between the two items by delta lines or
+// columns.
+func (l *layoutLevel) nudgeSplit(delta int) error {
+	size, length := l.items[0].size, l.rect.X1-l.rect.X0+1
+	if l.direction == LayoutVertical {
+		length = l.rect.Y1 - l.rect.Y0 + 1
+	}
+
+	// Round up, so the first item gets at least the new size.
+	percent := ((size+delta)*100 + length - 1) / length
+	if percent < 1 || percent > 99 {
+		return nil
+	}
+	l.record()
+	l.splitter.percent = percent
+
+	return nil
+}
+
+// dragSplit moves the border between the two items to the given position.
+func (l *layoutLevel) dragSplit(x, y int) error {
+	pos, start, end := x, l.rect.X0, l.rect.X1
+	if l.direction == LayoutVertical {
+		pos, start, end = y, l.rect.Y0, l.rect.Y1
+	}
+
+	percent := (pos - start + 1) * 100 / (end - start + 1)
+	if percent < 1 {
+		percent = 1
+	} else if percent > 99 {
+		percent = 99
+	}
+	l.splitter.percent = percent
+	l.changed()
+
+	return nil
+}
+
+// layoutSash places an invisible view over the border between the two items,
+// to receive the clicks that start a drag.
+func (s *splitState) layoutSash(g *gocui.Gui, l *layoutLevel, forceHidden HideLayout) error {
+	if !s.drag || bool(forceHidden || l.items[0].isHidden() || l.items[1].isHidden()) {
+		if err := g.DeleteView(s.sash); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		return nil
+	}
+
+	r := l.items[0].rect
+	x0, y0, x1, y1 := r.X1-1, l.rect.Y0, r.X1+1, l.rect.Y1
+	if l.direction == LayoutVertical {
+		x0, y0, x1, y1 = l.rect.X0, r.Y1-1, l.rect.X1, r.Y1+1
+	}
+
+	v, err := g.SetView(s.sash, x0, y0, x1, y1, 0)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Visible = false
+		v.Frame = false
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSplitter(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewSplitter(LayoutHorizontal, 25,
+		NewRatioItem(1, "left"),
+		NewFixedItem(10, "right"),
+	)
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"left":  {0, 0, 19, 24},
+		"right": {20, 0, 79, 24},
+	})
+
+	if err := l.SetSplit(50); err != nil {
+		t.Fatalf("SetSplit failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"left":  {0, 0, 39, 24},
+		"right": {40, 0, 79, 24},
+	})
+
+	if err := l.dragSplit(59, 10); err != nil {
+		t.Fatalf("dragSplit failed: %v", err)
+	}
+	if got, _ := l.Split(); got != 75 {
+		t.Errorf("Unexpected split after drag: got %d, want 75", got)
+	}
+
+	if err := l.SetSplit(50); err != nil {
+		t.Fatalf("SetSplit failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.nudgeSplit(1); err != nil {
+		t.Fatalf("nudgeSplit failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"left":  {0, 0, 40, 24},
+		"right": {41, 0, 79, 24},
+	})
+
+	if err := l.ResetSplit(); err != nil {
+		t.Fatalf("ResetSplit failed: %v", err)
+	}
+	if got, _ := l.Split(); got != 25 {
+		t.Errorf("Unexpected split after reset: got %d, want 25", got)
+	}
+
+	if err := l.SetSplit(100); !errors.Is(err, InvalidValues) {
+		t.Errorf("Unexpected error for invalid split: got %v, want %v", err, InvalidValues)
+	}
+
+	if _, err := NewLevel(LayoutVertical).Split(); !errors.Is(err, NotSplitter) {
+		t.Errorf("Unexpected error for non-splitter: got %v, want %v", err, NotSplitter)
+	}
+}
+
+func TestSplitterSash(t *testing.T) {
+	g := newTestGui(t, true)
+	l := NewSplitter(LayoutVertical, 50,
+		NewRatioItem(1, "top"),
+		NewRatioItem(1, "bottom"),
+	)
+	if err := l.EnableDrag(g); err != nil {
+		t.Fatalf("EnableDrag failed: %v", err)
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"top":      {0, 0, 79, 12},
+		"bottom":   {0, 12, 79, 24},
+		"top~sash": {0, 11, 79, 13},
+	})
+
+	if err := l.HideItem("bottom", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.View("top~sash"); err == nil {
+		t.Errorf("Sash still exists with a hidden item")
+	}
+}
@@ -0,0 +1,55 @@
+package layout
+
+import (
+	"errors"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Stats describes a render of the layout, see OnStats.
+type Stats struct {
+	// The items rendered, displayed or hidden, including those holding
+	// levels or managers.
+	Items int
+	// The views of items placed with gocui's SetView, and how many of those
+	// were created, moved or resized. The rest were left where they were.
+	Views, Moved int
+	// The levels laid out, and how many of those reused the geometry of a
+	// previous render rather than computing it again.
+	Levels, Cached int
+	// How long the render took.
+	Duration time.Duration
+	// The errors from rendering, including those passed to the handler set
+	// with SetErrorHandler.
+	Errors int
+}
+
+// OnStats sets a function to call after each render of the layout with
+// statistics about it, such as to monitor the performance of large layouts.
+// A nil f removes it.
+func (l *layoutLevel) OnStats(f func(Stats)) {
+	l.onStats = f
+}
+
+// notifyStats calls the function set with OnStats with the statistics of the
+// render that started at start and returned err.
+func (l *layoutLevel) notifyStats(g *gocui.Gui, start time.Time, err error) {
+	if l.onStats == nil {
+		return
+	}
+
+	s := l.stats
+	s.Duration = time.Since(start)
+	var errs Errors
+	if errors.As(err, &errs) {
+		s.Errors = len(errs)
+	} else if err != nil && s.Errors == 0 {
+		s.Errors = 1
+	}
+	f := l.onStats
+	call(g, l.deferCallbacks, nil, func() error {
+		f(s)
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestOnStats(t *testing.T) {
+	g := newTestGui(t, false)
+	failed := errors.New("failed")
+	fail := false
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a", WithUpdate(func(*gocui.View) error {
+			if fail {
+				return failed
+			}
+			return nil
+		})),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c", Hidden()),
+		))),
+	)
+	var got Stats
+	l.OnStats(func(s Stats) {
+		got = s
+	})
+	render := func(want Stats) {
+		t.Helper()
+		l.Layout(g)
+		if got.Duration <= 0 {
+			t.Errorf("Expected a duration, got %v", got.Duration)
+		}
+		got.Duration = 0
+		if got != want {
+			t.Errorf("Unexpected stats:\ngot  %+v\nwant %+v", got, want)
+		}
+	}
+
+	render(Stats{Items: 4, Views: 3, Moved: 3, Levels: 2})
+	// The second time, nothing moves and the geometry is reused.
+	render(Stats{Items: 4, Views: 3, Levels: 2, Cached: 2})
+
+	if err := l.ResizeItem("a", 0, 20); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	l.ContinueOnError(true)
+	fail = true
+	render(Stats{Items: 4, Views: 3, Moved: 3, Levels: 2, Errors: 1})
+}
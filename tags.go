@@ -0,0 +1,24 @@
+package layout
+
+// WithTags labels the item, such as by the kind of content it displays, so
+// that groups of items can be found with Tagged.
+func WithTags(tags ...string) layoutItemOption {
+	return func(l *layoutItem) {
+		l.tags = append(l.tags, tags...)
+	}
+}
+
+// Tagged returns the names of the items labeled with the tag using WithTags,
+// in the order they appear in the layout.
+func (l *layoutLevel) Tagged(tag string) []string {
+	var names []string
+	l.walk(func(item *layoutItem, _ *ItemInfo) {
+		for _, t := range item.tags {
+			if t == tag {
+				names = append(names, item.name)
+				return
+			}
+		}
+	})
+	return names
+}
@@ -0,0 +1,24 @@
+package layout
+
+// Takeover temporarily replaces everything the layout displays with another
+// layout, such as a full screen help or editor. The original views are kept
+// alive, hidden, until EndTakeover is called. Calling Takeover again replaces
+// the previous takeover.
+func (l *layoutLevel) Takeover(level *layoutLevel) {
+	if l.takeover != nil && l.takeover != level {
+		l.dropItems(l.takeover)
+	}
+	l.takeover = level
+	l.changed()
+}
+
+// EndTakeover restores the original layout, and deletes the views of the
+// takeover layout on the next render.
+func (l *layoutLevel) EndTakeover() {
+	if l.takeover == nil {
+		return
+	}
+	l.dropItems(l.takeover)
+	l.takeover = nil
+	l.changed()
+}
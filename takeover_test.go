@@ -0,0 +1,45 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestTakeover(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "left"),
+		NewRatioItem(1, "right"),
+	)
+	help := NewLevel(LayoutVertical, NewRatioItem(1, "help"))
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	l.Takeover(help)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"help":  {0, 0, 79, 24},
+		"left":  {0, 0, 79, 24},
+		"right": {0, 0, 79, 24},
+	})
+	if v, err := g.ViewByPosition(10, 10); err != nil || v.Name() != "help" {
+		t.Errorf("Takeover layout is not on top")
+	}
+
+	l.EndTakeover()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"left":  {0, 0, 39, 24},
+		"right": {40, 0, 79, 24},
+	})
+	if _, err := g.View("help"); err != gocui.ErrUnknownView {
+		t.Errorf("Takeover view was not deleted")
+	}
+}
@@ -0,0 +1,120 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// tooSmallView is the name of the view displayed when the screen is too small
+// for the layout.
+const tooSmallView = "_layoutTooSmall"
+
+// ShowTooSmall sets whether a placeholder is displayed when the screen is too
+// small for the layout, instead of returning a TooSmall error. The placeholder
+// covers the whole layout and gives the size needed, until there is enough
+// space again.
+func (l *layoutLevel) ShowTooSmall(show bool) {
+	l.tooSmall = show
+}
+
+func (l *layoutLevel) layoutTooSmall(g *gocui.Gui, x0, y0, x1, y1 int) error {
+	v, err := g.SetView(tooSmallView, x0-1, y0-1, x1+1, y1+1, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Frame = false
+	v.Wrap = true
+	v.Clear()
+
+	var overlap int
+	if !g.SupportOverlaps {
+		overlap = 1
+	}
+	w, h := l.minSize(overlap)
+	fmt.Fprintf(v, "Terminal too small\nNeed at least %dx%d\n", w, h)
+
+	_, err = g.SetViewOnTop(tooSmallView)
+	return err
+}
+
+// MinSize returns the smallest screen the layout can be rendered in, with every
+// visible item getting at least its fixed size, or a single line and two
+// columns for views. As with Compute, the size is for a gui that does not
+// support overlapping views.
+func (l *layoutLevel) MinSize() (w, h int) {
+	return l.minSize(1)
+}
+
+// minSize returns the smallest width and height in which the layout can be
+// rendered.
+func (l *layoutLevel) minSize(overlap int) (int, int) {
+	if l.takeover != nil {
+		return l.takeover.minSize(overlap)
+	}
+
+	var along, across int
+	mins := make([]int, len(l.items))
+	for idx, item := range l.items {
+		if l.hidden(idx) {
+			continue
+		}
+
+		// Views need to be at least two columns wide.
+		w, h := 2, 1
+		if item.inner != nil {
+			w, h = item.inner.minSize(overlap)
+		} else if item.manager != nil {
+			w, h = 1, 1
+		}
+		if l.direction == LayoutVertical {
+			w, h = h, w
+		}
+
+		mins[idx] = w
+		if item.fixed > 0 {
+			along += item.fixed
+		} else {
+			along += w
+		}
+		if h > across {
+			across = h
+		}
+	}
+
+	// Grow the level until every ratio item gets its minimum.
+	for ; ; along++ {
+		x1, y1 := along-1, across-1
+		if l.direction == LayoutVertical {
+			x1, y1 = y1, x1
+		}
+		places, err := l.arrange(0, 0, x1, y1, overlap, LayoutVisible)
+		if err == nil && l.fits(places, mins) {
+			break
+		}
+	}
+
+	if l.direction == LayoutVertical {
+		return across, along
+	}
+	return along, across
+}
+
+// fits reports if all the visible ratio items were given at least their
+// minimum length.
+func (l *layoutLevel) fits(places []placement, mins []int) bool {
+	for idx, item := range l.items {
+		if places[idx].hidden || item.fixed > 0 {
+			continue
+		}
+		r := places[idx].rect
+		length := r.X1 - r.X0 + 1
+		if l.direction == LayoutVertical {
+			length = r.Y1 - r.Y0 + 1
+		}
+		if length < mins[idx] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestShowTooSmall(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewFixedItem(90, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewFixedItem(30, "c"),
+			NewRatioItem(1, "d"),
+		))),
+	)
+
+	if err := l.Layout(g); !errors.Is(err, TooSmall) {
+		t.Errorf("Unexpected error: got %v, want %v", err, TooSmall)
+	}
+
+	l.ShowTooSmall(true)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	v, err := g.View(tooSmallView)
+	if err != nil {
+		t.Fatalf("Placeholder not found: %v", err)
+	}
+	want := "Terminal too small\nNeed at least 92x31\n"
+	if got := v.Buffer(); got != want {
+		t.Errorf("Unexpected placeholder: want %q, got %q", want, got)
+	}
+
+	if err := l.ResizeItem("a", 0, 10); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	if err := l.ResizeItem("c", 0, 10); err != nil {
+		t.Fatalf("ResizeItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if viewExists(g, tooSmallView) {
+		t.Errorf("Placeholder was not removed")
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 9, 24},
+		"c": {10, 0, 79, 9},
+	})
+}
+
+func TestMinSize(t *testing.T) {
+	tests := []struct {
+		desc string
+		l    *layoutLevel
+		w, h int
+	}{
+		{
+			desc: "views",
+			l: NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "a"),
+				NewRatioItem(3, "b"),
+			),
+			w: 8, h: 1,
+		},
+		{
+			desc: "fixed",
+			l: NewLevel(LayoutVertical,
+				NewFixedItem(3, "header"),
+				NewRatioItem(1, "body", WithInner(NewLevel(LayoutHorizontal,
+					NewFixedItem(20, "sidebar"),
+					NewRatioItem(1, "main"),
+				))),
+				NewFixedItem(1, "status"),
+			),
+			w: 22, h: 5,
+		},
+		{
+			desc: "weighted",
+			l: NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "a", WithInner(NewLevel(LayoutHorizontal,
+					NewFixedItem(10, "b"),
+				))),
+				NewRatioItem(1, "c"),
+				NewRatioItem(1, "d", Hidden()),
+			),
+			w: 20, h: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			w, h := tc.l.MinSize()
+			if w != tc.w || h != tc.h {
+				t.Errorf("want %dx%d, got %dx%d", tc.w, tc.h, w, h)
+			}
+			if _, err := tc.l.Compute(w, h); err != nil {
+				t.Errorf("Compute failed at the minimum size: %v", err)
+			}
+		})
+	}
+}
+
+func TestEmptyScreen(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewFixedItem(10, "b"),
+	)
+
+	// A screen with no size, or a single line or column, displays nothing.
+	for _, r := range []Rect{{0, 0, -1, -1}, {0, 0, 79, -1}, {0, 0, -1, 24}, {0, 0, 0, 0}, {0, 0, 79, 0}, {0, 0, 0, 24}} {
+		if err := l.LayoutRect(g, r.X0, r.Y0, r.X1, r.Y1); err != nil {
+			t.Errorf("LayoutRect(%v) failed: %v", r, err)
+		}
+		if viewExists(g, "a") || viewExists(g, tooSmallView) {
+			t.Errorf("LayoutRect(%v) created views", r)
+		}
+	}
+
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 69, 24},
+		"b": {70, 0, 79, 24},
+	})
+}
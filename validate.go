@@ -0,0 +1,118 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EmptyLevel is an error returned when a level has no items.
+var EmptyLevel = fmt.Errorf("Level has no items")
+
+// Validate checks the layout for mistakes that would otherwise only show up
+// when it is rendered, or not at all: names used more than once, levels
+// without items, items with both or neither of a ratio and a fixed size, and
+// items holding a level that also have create or update functions, which are
+// never called. All the problems found are returned together as Errors.
+func (l *layoutLevel) Validate() error {
+	var errs Errors
+	if len(l.items) == 0 {
+		errs = append(errs, EmptyLevel)
+	}
+	l.validate(nil, make(map[string]bool), &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Build finishes constructing a layout, returning it if Validate finds no
+// problems with it, so that mistakes such as two items sharing a name, and so
+// fighting over one view, are caught before it is rendered:
+//
+//	l, err := NewLevel(LayoutHorizontal, items...).Build()
+func (l *layoutLevel) Build() (*layoutLevel, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *layoutLevel) validate(path []string, seen map[string]bool, errs *Errors) {
+	fail := func(name string, err error) {
+		*errs = append(*errs, &ItemError{
+			Item: name,
+			Path: append([]string(nil), path...),
+			Err:  err,
+		})
+	}
+
+	for _, item := range l.items {
+		if seen[item.name] {
+			fail(item.name, DuplicateName)
+		}
+		seen[item.name] = true
+
+		if (item.ratio != 0) == (item.fixed != 0) || item.ratio < 0 || item.fixed < 0 {
+			fail(item.name, InvalidValues)
+		}
+
+		levels := item.levels()
+		if len(levels) == 0 {
+			continue
+		}
+
+		if item.fNew != nil || item.fUpdate != nil {
+			fail(item.name, fmt.Errorf("%w: functions set on an item holding a level", InvalidValues))
+		}
+		// Views in other routes may share names, as only one route is
+		// displayed at a time.
+		before := copySet(seen)
+		for _, level := range levels {
+			names := seen
+			if level != item.inner {
+				names = copySet(before)
+			}
+			if level.name != "" {
+				if names[level.name] {
+					fail(level.name, DuplicateName)
+				}
+				names[level.name] = true
+			}
+			if len(level.items) == 0 {
+				fail(item.name, EmptyLevel)
+			}
+			level.validate(append(path, item.name), names, errs)
+		}
+	}
+}
+
+// levels returns the levels held by the item, starting with the displayed one
+// followed by any other routes, sorted by name.
+func (l *layoutItem) levels() []*layoutLevel {
+	var levels []*layoutLevel
+	if l.inner != nil {
+		levels = append(levels, l.inner)
+	}
+	if l.router != nil {
+		var names []string
+		for name := range l.router.routes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if level := l.router.routes[name]; level != l.inner {
+				levels = append(levels, level)
+			}
+		}
+	}
+	return levels
+}
+
+func copySet(set map[string]bool) map[string]bool {
+	c := make(map[string]bool, len(set))
+	for k, v := range set {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,102 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestValidate(t *testing.T) {
+	noop := func(*gocui.View) error { return nil }
+
+	tests := []struct {
+		desc string
+		l    *layoutLevel
+		want []string
+	}{
+		{
+			desc: "valid",
+			l: NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "a", WithUpdate(noop)),
+				NewRatioItem(1, "b", WithRoutes(map[string]*layoutLevel{
+					"one": NewLevel(LayoutVertical, NewRatioItem(1, "c")),
+					"two": NewLevel(LayoutVertical, NewRatioItem(1, "c")),
+				}, "one")),
+			),
+		},
+		{
+			desc: "empty layout",
+			l:    NewLevel(LayoutHorizontal),
+			want: []string{EmptyLevel.Error()},
+		},
+		{
+			desc: "problems",
+			l: NewLevel(LayoutHorizontal,
+				NewRatioItem(1, "a"),
+				NewRatioItem(1, "b", WithCreate(noop), WithInner(NewNamedLevel("a", LayoutVertical,
+					NewRatioItem(1, "c"),
+					NewRatioItem(1, "d", WithInner(NewLevel(LayoutVertical))),
+				))),
+				NewRatioItem(1, "c"),
+				&layoutItem{name: "e"},
+			),
+			want: []string{
+				"item 'b': " + InvalidValues.Error() + ": functions set on an item holding a level",
+				"item 'a': " + DuplicateName.Error(),
+				"item 'd' in level b: " + EmptyLevel.Error(),
+				"item 'c': " + DuplicateName.Error(),
+				"item 'e': " + InvalidValues.Error(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.l.Validate()
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(errs) != len(tc.want) {
+				t.Fatalf("Unexpected errors: want %q, got %v", tc.want, err)
+			}
+			for i, want := range tc.want {
+				if got := errs[i].Error(); got != want {
+					t.Errorf("Unexpected error %d: want %q, got %q", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	l, err := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "b", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "a"),
+		))),
+	).Build()
+	if !errors.Is(err, DuplicateName) {
+		t.Errorf("Expected DuplicateName, got %v", err)
+	}
+	if l != nil {
+		t.Errorf("Expected no layout, got %v", l)
+	}
+
+	want := NewLevel(LayoutHorizontal, NewRatioItem(1, "a"), NewRatioItem(1, "b"))
+	l, err = want.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if l != want {
+		t.Errorf("Build returned a different layout")
+	}
+}
@@ -0,0 +1,70 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// VisibilityChange lists the views that were shown and hidden by a render of
+// the layout, see OnVisibilityChange.
+type VisibilityChange struct {
+	// The views displayed that weren't before, in layout order.
+	Shown []string
+	// The views no longer displayed, in the order they were.
+	Hidden []string
+}
+
+// OnVisibilityChange sets a function to call once per render of the layout
+// that changed which views are displayed, with all the views shown and hidden
+// since the previous one. Changes that affect several views at once, such as
+// hiding an item holding a level, zooming or switching routes, are reported
+// together. On the first render, every displayed view is shown. A nil f
+// removes it.
+func (l *layoutLevel) OnVisibilityChange(f func(VisibilityChange)) {
+	l.fVisibility = f
+}
+
+// notifyVisibility calls the function set with OnVisibilityChange if the
+// views displayed changed since the last time. They only change with the
+// tree, so there's nothing to check if it hasn't changed since.
+func (l *layoutLevel) notifyVisibility(g *gocui.Gui, version uint64) {
+	if l.fVisibility == nil {
+		l.visible = nil
+		return
+	}
+	if l.visible != nil && version == l.visibleVersion {
+		return
+	}
+	l.visibleVersion = version
+
+	now := l.VisibleItems()
+	before := make(map[string]bool, len(l.visible))
+	for _, name := range l.visible {
+		before[name] = true
+	}
+	after := make(map[string]bool, len(now))
+	var c VisibilityChange
+	for _, name := range now {
+		after[name] = true
+		if !before[name] {
+			c.Shown = append(c.Shown, name)
+		}
+	}
+	for _, name := range l.visible {
+		if !after[name] {
+			c.Hidden = append(c.Hidden, name)
+		}
+	}
+
+	// Not nil once rendered, even with nothing displayed.
+	l.visible = now
+	if l.visible == nil {
+		l.visible = []string{}
+	}
+	if len(c.Shown) > 0 || len(c.Hidden) > 0 {
+		f := l.fVisibility
+		call(g, l.deferCallbacks, nil, func() error {
+			f(c)
+			return nil
+		})
+	}
+}
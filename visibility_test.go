@@ -0,0 +1,64 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOnVisibilityChange(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+	var changes []VisibilityChange
+	l.OnVisibilityChange(func(c VisibilityChange) {
+		changes = append(changes, c)
+	})
+	render := func() {
+		t.Helper()
+		if err := l.Layout(g); err != nil {
+			t.Fatalf("Layout failed: %v", err)
+		}
+	}
+
+	render()
+	render()
+	want := []VisibilityChange{{Shown: []string{"a", "b", "c"}}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Unexpected changes: got %+v, want %+v", changes, want)
+	}
+
+	// Hiding a level is reported at once, with all its views.
+	if err := l.HideItem("col", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	render()
+	want = append(want, VisibilityChange{Hidden: []string{"b", "c"}})
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Unexpected changes: got %+v, want %+v", changes, want)
+	}
+
+	// Changes between renders are coalesced, and those undone don't count.
+	if err := l.HideItem("col", LayoutVisible); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.HideItem("a", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.HideItem("c", LayoutHidden); err != nil {
+		t.Fatalf("HideItem failed: %v", err)
+	}
+	if err := l.Zoom("c"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	l.Unzoom()
+	render()
+	want = append(want, VisibilityChange{Shown: []string{"b"}, Hidden: []string{"a"}})
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Unexpected changes: got %+v, want %+v", changes, want)
+	}
+}
@@ -0,0 +1,87 @@
+package layout
+
+import (
+	"fmt"
+)
+
+// WizardLayout displays a sequence of steps one at a time, below a header
+// item that stays in place, such as a progress bar. It can be used directly as
+// a gui's manager.
+type WizardLayout struct {
+	*layoutLevel
+	steps      *layoutLevel
+	onStep     func(step int) error
+	onComplete func() error
+}
+
+// NewWizard creates a wizard with the given header, usually a fixed item, and
+// steps. The first step is displayed initially.
+func NewWizard(header *layoutItem, steps ...*layoutItem) *WizardLayout {
+	carousel := NewCarousel(steps...)
+
+	return &WizardLayout{
+		layoutLevel: NewLevel(LayoutVertical,
+			header,
+			NewRatioItem(1, "_wizardSteps", WithInner(carousel)),
+		),
+		steps: carousel,
+	}
+}
+
+// Step returns the index of the displayed step.
+func (w *WizardLayout) Step() int {
+	return w.steps.carousel.current
+}
+
+// OnStep sets a function to be called with the new step's index whenever the
+// displayed step changes.
+func (w *WizardLayout) OnStep(f func(step int) error) {
+	w.onStep = f
+}
+
+// OnComplete sets a function to be called when NextStep is called on the last
+// step.
+func (w *WizardLayout) OnComplete(f func() error) {
+	w.onComplete = f
+}
+
+// NextStep displays the following step. On the last step, it calls the
+// OnComplete function instead.
+func (w *WizardLayout) NextStep() error {
+	if w.Step() == len(w.steps.items)-1 {
+		if w.onComplete != nil {
+			return w.onComplete()
+		}
+		return nil
+	}
+
+	return w.GoTo(w.Step() + 1)
+}
+
+// PrevStep displays the preceding step. It does nothing on the first step.
+func (w *WizardLayout) PrevStep() error {
+	if w.Step() == 0 {
+		return nil
+	}
+
+	return w.GoTo(w.Step() - 1)
+}
+
+// GoTo displays the step at the given index.
+func (w *WizardLayout) GoTo(step int) error {
+	if step < 0 || step >= len(w.steps.items) {
+		return w.itemError("_wizardSteps", fmt.Errorf("%w: step %d", InvalidValues, step))
+	}
+
+	if step == w.Step() {
+		return nil
+	}
+
+	w.steps.carousel.current = step
+	w.steps.changed()
+	if w.onStep != nil {
+		return w.onStep(step)
+	}
+
+	return nil
+}
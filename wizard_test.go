@@ -0,0 +1,58 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWizard(t *testing.T) {
+	g := newTestGui(t, false)
+	w := NewWizard(NewFixedItem(3, "progress"),
+		NewRatioItem(1, "welcome"),
+		NewRatioItem(1, "options"),
+		NewRatioItem(1, "confirm"),
+	)
+	var steps []int
+	completed := 0
+	w.OnStep(func(step int) error {
+		steps = append(steps, step)
+		return nil
+	})
+	w.OnComplete(func() error {
+		completed++
+		return nil
+	})
+
+	for _, f := range []func() error{w.PrevStep, w.NextStep, w.NextStep, w.NextStep, w.PrevStep} {
+		if err := f(); err != nil {
+			t.Fatalf("Changing steps failed: %v", err)
+		}
+	}
+	if err := w.GoTo(0); err != nil {
+		t.Fatalf("GoTo failed: %v", err)
+	}
+
+	want := []int{1, 2, 1, 0}
+	if len(steps) != len(want) {
+		t.Fatalf("Unexpected steps: got %v, want %v", steps, want)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Fatalf("Unexpected steps: got %v, want %v", steps, want)
+		}
+	}
+	if completed != 1 {
+		t.Errorf("Unexpected completions: got %d, want 1", completed)
+	}
+	if err := w.GoTo(3); !errors.Is(err, InvalidValues) {
+		t.Errorf("Unexpected error for invalid step: got %v, want %v", err, InvalidValues)
+	}
+
+	if err := w.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"progress": {0, 0, 79, 2},
+		"welcome":  {0, 3, 79, 24},
+	})
+}
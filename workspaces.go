@@ -0,0 +1,115 @@
+package layout
+
+import (
+	"github.com/awesome-gocui/gocui"
+)
+
+// Workspaces holds several complete layouts, of which one is displayed at a
+// time. It can be passed to gocui's SetManager instead of a single layout.
+type Workspaces struct {
+	levels  map[string]*layoutLevel
+	names   []string
+	current string
+
+	// The workspace last rendered, whose item keybindings are deleted once
+	// another one is.
+	rendered string
+}
+
+// NewWorkspaces creates an empty set of workspaces. The first workspace added
+// is the one initially displayed.
+func NewWorkspaces() *Workspaces {
+	return &Workspaces{levels: make(map[string]*layoutLevel)}
+}
+
+// Add adds a layout as a new named workspace.
+func (w *Workspaces) Add(name string, l *layoutLevel) error {
+	if _, ok := w.levels[name]; ok {
+		return &ItemError{Item: name, Err: DuplicateName}
+	}
+
+	w.levels[name] = l
+	w.names = append(w.names, name)
+	if w.current == "" {
+		w.current = name
+	}
+
+	return nil
+}
+
+// Switch displays the named workspace. Views belonging only to the previous
+// workspace, and the keybindings of its items, are deleted on the next layout,
+// when the item of the workspace marked WithInitialFocus, if any, is focused.
+func (w *Workspaces) Switch(name string) error {
+	next, ok := w.levels[name]
+	if !ok {
+		return &ItemError{Item: name, Err: NotFound}
+	}
+
+	if prev, ok := w.levels[w.current]; ok && prev != next {
+		next.dropItems(prev)
+	}
+	w.current = name
+	next.focusedInitially = false
+
+	return nil
+}
+
+// Current returns the name of the displayed workspace.
+func (w *Workspaces) Current() string {
+	return w.current
+}
+
+// Names returns the names of all workspaces, in the order they were added.
+func (w *Workspaces) Names() []string {
+	return append([]string(nil), w.names...)
+}
+
+// Workspace returns the layout of the named workspace.
+func (w *Workspaces) Workspace(name string) (*layoutLevel, error) {
+	l, ok := w.levels[name]
+	if !ok {
+		return nil, &ItemError{Item: name, Err: NotFound}
+	}
+
+	return l, nil
+}
+
+// Prune deletes the views created by any of the workspaces that are not part
+// of the current one.
+func (w *Workspaces) Prune(g *gocui.Gui) error {
+	l, ok := w.levels[w.current]
+	if !ok {
+		return nil
+	}
+
+	keep := l.keep()
+	for _, level := range w.levels {
+		if err := level.prune(g, keep); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Layout renders the current workspace.
+func (w *Workspaces) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	return w.LayoutRect(g, 0, 0, maxX-1, maxY-1)
+}
+
+// LayoutRect renders the current workspace within the given rectangle.
+func (w *Workspaces) LayoutRect(g *gocui.Gui, x0, y0, x1, y1 int) error {
+	l, ok := w.levels[w.current]
+	if !ok {
+		return nil
+	}
+
+	if prev, ok := w.levels[w.rendered]; ok && prev != l {
+		prev.unbindItemKeys(g)
+	}
+	w.rendered = w.current
+
+	return l.LayoutRect(g, x0, y0, x1, y1)
+}
@@ -0,0 +1,102 @@
+package layout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestWorkspaces(t *testing.T) {
+	g := newTestGui(t, false)
+	w := NewWorkspaces()
+	if err := w.Add("edit", NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "files"),
+		NewRatioItem(3, "editor"),
+	)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := w.Add("debug", NewLevel(LayoutVertical,
+		NewRatioItem(1, "editor"),
+		NewRatioItem(1, "stack"),
+	)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := w.Add("edit", NewLevel(LayoutVertical)); !errors.Is(err, DuplicateName) {
+		t.Errorf("Unexpected error for duplicate: got %v, want %v", err, DuplicateName)
+	}
+
+	if err := w.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"files":  {0, 0, 19, 24},
+		"editor": {20, 0, 79, 24},
+	})
+
+	if err := w.Switch("debug"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	if err := w.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"editor": {0, 0, 79, 11},
+		"stack":  {0, 12, 79, 24},
+	})
+	if _, err := g.View("files"); err != gocui.ErrUnknownView {
+		t.Errorf("View of the previous workspace was not deleted")
+	}
+
+	if got := w.Current(); got != "debug" {
+		t.Errorf("Unexpected current workspace: got %q, want %q", got, "debug")
+	}
+	if err := w.Switch("missing"); !errors.Is(err, NotFound) {
+		t.Errorf("Unexpected error for missing workspace: got %v, want %v", err, NotFound)
+	}
+}
+
+func TestWorkspacesKeybindings(t *testing.T) {
+	g := newTestGui(t, false)
+	save := func(*gocui.Gui, *gocui.View) error { return nil }
+	edit := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "files"),
+		NewRatioItem(3, "editor", WithKeybinding(gocui.KeyCtrlS, gocui.ModNone, save)),
+	)
+	w := NewWorkspaces()
+	if err := w.Add("edit", edit); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := w.Add("debug", NewLevel(LayoutVertical,
+		NewRatioItem(1, "editor"),
+		NewRatioItem(1, "console"),
+	)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := w.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, ok := edit.keysBound["editor"]; !ok {
+		t.Fatalf("Expected the editor's keys to be bound")
+	}
+
+	if err := w.Switch("debug"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	if err := w.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := g.DeleteKeybinding("editor", gocui.KeyCtrlS, gocui.ModNone); err == nil {
+		t.Errorf("Expected the editor's keys to be deleted with its workspace switched out")
+	}
+
+	if err := w.Switch("edit"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	if err := w.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := g.DeleteKeybinding("editor", gocui.KeyCtrlS, gocui.ModNone); err != nil {
+		t.Errorf("Expected the editor's keys to be bound again: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package layout
+
+import (
+	"errors"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Zoom displays the named item over the whole layout, hiding the rest of it,
+// until Unzoom is called. Zooming another item replaces the previous one.
+func (l *layoutLevel) Zoom(name string) error {
+	item, err := l.findItem(name)
+	if err != nil {
+		return err
+	}
+
+	l.zoomed = item
+	l.changed()
+	return nil
+}
+
+// Unzoom displays the whole layout again after Zoom.
+func (l *layoutLevel) Unzoom() {
+	if l.zoomed == nil {
+		return
+	}
+	l.zoomed = nil
+	l.changed()
+}
+
+// Zoomed returns the name of the zoomed item, or "" if none is.
+func (l *layoutLevel) Zoomed() string {
+	if l.zoomed == nil {
+		return ""
+	}
+	return l.zoomed.name
+}
+
+// checkZoom unzooms the layout if the zoomed item was since removed from it.
+func (l *layoutLevel) checkZoom() {
+	if l.zoomed == nil {
+		return
+	}
+	if item, err := l.findItem(l.zoomed.name); err != nil || item != l.zoomed {
+		l.zoomed = nil
+	}
+}
+
+// ToggleZoom zooms the item of the gui's current view, or unzooms the layout
+// if an item is zoomed. Once unzoomed, the zoomed item is focused on the next
+// render, if it can be, so that it keeps the focus even if it was zoomed with
+// Zoom rather than from its view.
+func (l *layoutLevel) ToggleZoom(g *gocui.Gui) error {
+	if l.zoomed != nil {
+		name := l.zoomed.name
+		l.Unzoom()
+		if err := l.Focus(name); err != nil && !errors.Is(err, Unfocusable) {
+			return err
+		}
+		return nil
+	}
+	if name, ok := l.focusedItem(g); ok {
+		return l.Zoom(name)
+	}
+	return nil
+}
+
+// BindZoom binds key, with mod, for all views, to ToggleZoom. BindDefaults
+// binds Alt-z to the same.
+func (l *layoutLevel) BindZoom(g *gocui.Gui, key interface{}, mod gocui.Modifier) error {
+	return l.bindHelp(g, key, mod, actionHelp[ActionZoom], func(g *gocui.Gui, _ *gocui.View) error {
+		return l.ToggleZoom(g)
+	})
+}
@@ -0,0 +1,138 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+func TestZoom(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+
+	if err := l.Zoom("b"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {0, 0, 79, 24},
+	})
+	if got, want := l.VisibleItems(), []string{"b"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Unexpected visible items: want %v, got %v", want, got)
+	}
+	if top := g.Views()[len(g.Views())-1]; top.Name() != "b" {
+		t.Errorf("Expected b on top, got %q", top.Name())
+	}
+
+	if err := l.Zoom("col"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {0, 0, 79, 11},
+		"c": {0, 12, 79, 24},
+	})
+	if got := l.Zoomed(); got != "col" {
+		t.Errorf("Expected col to be zoomed, got %q", got)
+	}
+
+	l.Unzoom()
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	checkViews(t, g, map[string]size{
+		"a": {0, 0, 39, 24},
+		"b": {40, 0, 79, 11},
+		"c": {40, 12, 79, 24},
+	})
+
+	// Removing the zoomed item unzooms the layout.
+	if err := l.Zoom("a"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	if err := l.RemoveItem("a"); err != nil {
+		t.Fatalf("RemoveItem failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if got := l.Zoomed(); got != "" {
+		t.Errorf("Expected nothing to be zoomed, got %q", got)
+	}
+	checkViews(t, g, map[string]size{
+		"b": {0, 0, 79, 11},
+	})
+}
+
+func TestToggleZoom(t *testing.T) {
+	g := newTestGui(t, false)
+	l := NewLevel(LayoutHorizontal,
+		NewRatioItem(1, "a"),
+		NewRatioItem(1, "col", WithInner(NewLevel(LayoutVertical,
+			NewRatioItem(1, "b"),
+			NewRatioItem(1, "c"),
+		))),
+	)
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if _, err := g.SetCurrentView("c"); err != nil {
+		t.Fatalf("SetCurrentView failed: %v", err)
+	}
+
+	if err := l.ToggleZoom(g); err != nil {
+		t.Fatalf("ToggleZoom failed: %v", err)
+	}
+	if got := l.Zoomed(); got != "c" {
+		t.Errorf("Expected c to be zoomed, got %q", got)
+	}
+
+	if err := l.ToggleZoom(g); err != nil {
+		t.Fatalf("ToggleZoom failed: %v", err)
+	}
+	if got := l.Zoomed(); got != "" {
+		t.Errorf("Expected nothing to be zoomed, got %q", got)
+	}
+
+	// The zoomed item is focused once unzoomed, even if zoomed by name.
+	if err := l.Zoom("a"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if err := l.ToggleZoom(g); err != nil {
+		t.Fatalf("ToggleZoom failed: %v", err)
+	}
+	if err := l.Layout(g); err != nil {
+		t.Fatalf("Layout failed: %v", err)
+	}
+	if v := g.CurrentView(); v == nil || v.Name() != "a" {
+		t.Errorf("Expected a to be focused, got %v", v)
+	}
+
+	// Containers can't be focused, and are just unzoomed.
+	if err := l.Zoom("col"); err != nil {
+		t.Fatalf("Zoom failed: %v", err)
+	}
+	if err := l.ToggleZoom(g); err != nil {
+		t.Fatalf("ToggleZoom failed: %v", err)
+	}
+
+	if err := l.BindZoom(g, gocui.KeyF11, gocui.ModNone); err != nil {
+		t.Fatalf("BindZoom failed: %v", err)
+	}
+}